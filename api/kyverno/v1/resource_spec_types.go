@@ -1,5 +1,11 @@
 package v1
 
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type ResourceSpec struct {
 	// APIVersion specifies resource apiVersion.
 	// +optional
@@ -18,3 +24,28 @@ func (s ResourceSpec) GetName() string       { return s.Name }
 func (s ResourceSpec) GetNamespace() string  { return s.Namespace }
 func (s ResourceSpec) GetKind() string       { return s.Kind }
 func (s ResourceSpec) GetAPIVersion() string { return s.APIVersion }
+
+// TargetResourceSpec defines the resources to be mutated by a mutate existing rule.
+type TargetResourceSpec struct {
+	// ResourceSpec contains the target resources to load and mutate.
+	ResourceSpec `json:",inline" yaml:",inline"`
+
+	// Selector is a label selector used to filter the target resources.
+	// Filtering is done by the API server when listing the resources.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	// NamespaceSelector is a label selector used to filter the namespaces of the target resources.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
+	// Preconditions are used to determine if a target resource should be mutated.
+	// The target resource is available in the `target` variable.
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	RawAnyAllConditions *apiextv1.JSON `json:"preconditions,omitempty" yaml:"preconditions,omitempty"`
+}
+
+func (s *TargetResourceSpec) GetAnyAllConditions() apiextensions.JSON {
+	return FromJSON(s.RawAnyAllConditions)
+}
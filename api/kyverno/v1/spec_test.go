@@ -2,9 +2,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -49,3 +51,35 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_BackgroundScanSchedule(t *testing.T) {
+	maxResourcesPerSecond := int32(0)
+	subject := Spec{
+		BackgroundScanSchedule: &BackgroundScanSchedule{
+			Interval:              &metav1.Duration{Duration: 30 * time.Second},
+			MaxResourcesPerSecond: &maxResourcesPerSecond,
+		},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.backgroundScanSchedule.interval")
+	assert.Equal(t, errs[1].Field, "dummy.backgroundScanSchedule.maxResourcesPerSecond")
+	subject.BackgroundScanSchedule.Interval.Duration = time.Hour
+	maxResourcesPerSecond = 10
+	assert.Equal(t, len(subject.Validate(path, false, "", nil)), 0)
+	assert.Equal(t, subject.GetBackgroundScanInterval(), time.Hour)
+	assert.Equal(t, subject.GetBackgroundScanMaxResourcesPerSecond(), int32(10))
+	subject.BackgroundScanSchedule.Schedule = "*/5 * * * *"
+	errs = subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.backgroundScanSchedule.schedule")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+	subject.BackgroundScanSchedule.Interval = nil
+	assert.Equal(t, len(subject.Validate(path, false, "", nil)), 0)
+	assert.Equal(t, subject.GetBackgroundScanSchedule(), "*/5 * * * *")
+	subject.BackgroundScanSchedule.Schedule = "invalid"
+	errs = subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+}
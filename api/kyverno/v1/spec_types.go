@@ -2,8 +2,11 @@ package v1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -95,6 +98,72 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	GenerateExistingOnPolicyUpdate bool `json:"generateExistingOnPolicyUpdate,omitempty" yaml:"generateExistingOnPolicyUpdate,omitempty"`
+
+	// Order controls the position of this policy when mutate policies are applied to a resource.
+	// Policies with a lower order are applied first, policies with the same order are applied
+	// sorted by name. Each policy is applied to the resource patched by the previous ones.
+	// Defaults to 0.
+	// +optional
+	Order int32 `json:"order,omitempty" yaml:"order,omitempty"`
+
+	// AnnotateMutations controls if resources mutated by this policy are annotated with the
+	// policy rules that changed them and the top-level paths they changed.
+	// Defaults to "false" if not specified.
+	// +optional
+	AnnotateMutations bool `json:"annotateMutations,omitempty" yaml:"annotateMutations,omitempty"`
+
+	// BackgroundScanSchedule controls how existing resources are scanned against the policy
+	// during background scans. Only used when background processing is enabled.
+	// +optional
+	BackgroundScanSchedule *BackgroundScanSchedule `json:"backgroundScanSchedule,omitempty" yaml:"backgroundScanSchedule,omitempty"`
+}
+
+// minBackgroundScanInterval is the shortest interval allowed between periodic background scans
+const minBackgroundScanInterval = time.Minute
+
+// BackgroundScanSchedule controls the scheduling and throttling of background scans for a policy.
+type BackgroundScanSchedule struct {
+	// Interval is the period at which existing resources are rescanned against the policy, in addition
+	// to the scans triggered by resource and policy changes. The minimum value is one minute.
+	// Periodic rescans are disabled if neither interval nor schedule is specified.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+
+	// Schedule is the time at which existing resources are rescanned against the policy, in Cron format.
+	// It can't be used together with interval.
+	// +optional
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// MaxResourcesPerSecond limits the number of resources scanned against the policy per second.
+	// Scans are not throttled if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxResourcesPerSecond *int32 `json:"maxResourcesPerSecond,omitempty" yaml:"maxResourcesPerSecond,omitempty"`
+
+	// Priority controls the order in which periodic rescans are dispatched, when several policies are
+	// due at the same time the reports of a policy with a lower priority are only queued once the reports
+	// of the policies with a higher priority have been picked up, or after waiting for 30 seconds.
+	// Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *BackgroundScanSchedule) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Interval != nil && s.Interval.Duration < minBackgroundScanInterval {
+		errs = append(errs, field.Invalid(path.Child("interval"), s.Interval.Duration.String(), fmt.Sprintf("interval must be at least %s", minBackgroundScanInterval)))
+	}
+	if s.Schedule != "" {
+		if s.Interval != nil {
+			errs = append(errs, field.Forbidden(path.Child("schedule"), "schedule can't be used together with interval"))
+		} else if _, err := cron.ParseStandard(s.Schedule); err != nil {
+			errs = append(errs, field.Invalid(path.Child("schedule"), s.Schedule, "schedule must be in Cron format"))
+		}
+	}
+	if s.MaxResourcesPerSecond != nil && *s.MaxResourcesPerSecond < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxResourcesPerSecond"), *s.MaxResourcesPerSecond, "maxResourcesPerSecond must be at least 1"))
+	}
+	return errs
 }
 
 func (s *Spec) SetRules(rules []Rule) {
@@ -206,6 +275,48 @@ func (s *Spec) IsGenerateExistingOnPolicyUpdate() bool {
 	return s.GenerateExistingOnPolicyUpdate
 }
 
+// IsAnnotateMutations returns AnnotateMutations set value
+func (s *Spec) IsAnnotateMutations() bool {
+	return s.AnnotateMutations
+}
+
+// GetOrder returns the order in which mutate policies are applied
+func (s *Spec) GetOrder() int32 {
+	return s.Order
+}
+
+// GetBackgroundScanInterval returns the period of background rescans, zero if periodic rescans are disabled
+func (s *Spec) GetBackgroundScanInterval() time.Duration {
+	if s.BackgroundScanSchedule == nil || s.BackgroundScanSchedule.Interval == nil {
+		return 0
+	}
+	return s.BackgroundScanSchedule.Interval.Duration
+}
+
+// GetBackgroundScanSchedule returns the Cron schedule of background rescans, empty if not specified
+func (s *Spec) GetBackgroundScanSchedule() string {
+	if s.BackgroundScanSchedule == nil {
+		return ""
+	}
+	return s.BackgroundScanSchedule.Schedule
+}
+
+// GetBackgroundScanMaxResourcesPerSecond returns the background scan rate limit, zero if scans are not throttled
+func (s *Spec) GetBackgroundScanMaxResourcesPerSecond() int32 {
+	if s.BackgroundScanSchedule == nil || s.BackgroundScanSchedule.MaxResourcesPerSecond == nil {
+		return 0
+	}
+	return *s.BackgroundScanSchedule.MaxResourcesPerSecond
+}
+
+// GetBackgroundScanPriority returns the background scan priority
+func (s *Spec) GetBackgroundScanPriority() int32 {
+	if s.BackgroundScanSchedule == nil {
+		return 0
+	}
+	return s.BackgroundScanSchedule.Priority
+}
+
 // GetFailurePolicy returns the failure policy to be applied
 func (s *Spec) GetFailurePolicy() FailurePolicyType {
 	if toggle.ForceFailurePolicyIgnore.Enabled() {
@@ -259,5 +370,8 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	if s.BackgroundScanSchedule != nil {
+		errs = append(errs, s.BackgroundScanSchedule.Validate(path.Child("backgroundScanSchedule"))...)
+	}
 	return errs
 }
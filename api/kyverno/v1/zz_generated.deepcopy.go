@@ -208,6 +208,31 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackgroundScanSchedule) DeepCopyInto(out *BackgroundScanSchedule) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxResourcesPerSecond != nil {
+		in, out := &in.MaxResourcesPerSecond, &out.MaxResourcesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackgroundScanSchedule.
+func (in *BackgroundScanSchedule) DeepCopy() *BackgroundScanSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackgroundScanSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CTLog) DeepCopyInto(out *CTLog) {
 	*out = *in
@@ -898,8 +923,10 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 	*out = *in
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
-		*out = make([]ResourceSpec, len(*in))
-		copy(*out, *in)
+		*out = make([]TargetResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.RawPatchStrategicMerge != nil {
 		in, out := &in.RawPatchStrategicMerge, &out.RawPatchStrategicMerge
@@ -1327,6 +1354,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.BackgroundScanSchedule != nil {
+		in, out := &in.BackgroundScanSchedule, &out.BackgroundScanSchedule
+		*out = new(BackgroundScanSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spec.
@@ -1364,6 +1396,37 @@ func (in *StaticKeyAttestor) DeepCopy() *StaticKeyAttestor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetResourceSpec) DeepCopyInto(out *TargetResourceSpec) {
+	*out = *in
+	out.ResourceSpec = in.ResourceSpec
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RawAnyAllConditions != nil {
+		in, out := &in.RawAnyAllConditions, &out.RawAnyAllConditions
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetResourceSpec.
+func (in *TargetResourceSpec) DeepCopy() *TargetResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BlockedRequest describes an admission request blocked by a policy rule
+type BlockedRequest struct {
+	// Policy is the name of the policy that blocked the request, prefixed with its namespace for namespaced policies
+	Policy string `json:"policy"`
+
+	// Rule is the name of the rule that blocked the request
+	// +optional
+	Rule string `json:"rule,omitempty"`
+
+	// Result is the rule result that caused the request to be blocked, either fail or error
+	Result policyreportv1alpha2.PolicyResult `json:"result"`
+
+	// Message is the rule response message
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Resource is a reference to the resource of the blocked request
+	Resource corev1.ObjectReference `json:"resource"`
+
+	// Operation is the operation of the blocked request (CREATE, UPDATE, DELETE or CONNECT)
+	Operation string `json:"operation"`
+
+	// UID is the UID of the blocked admission request
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
+	// Username is the name of the user who made the request
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Groups are the groups of the user who made the request
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Timestamp is the time the request was blocked
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+type BlockedRequestReportSpec struct {
+	// Requests is the list of blocked admission requests, most recent last.
+	// Requests are split across several reports when they don't fit in a single one
+	// +optional
+	Requests []BlockedRequest `json:"requests,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=blockr,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BlockedRequestReport is the Schema for the BlockedRequestReports API
+type BlockedRequestReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BlockedRequestReportSpec `json:"spec"`
+}
+
+func (r *BlockedRequestReport) GetRequests() []BlockedRequest {
+	return r.Spec.Requests
+}
+
+func (r *BlockedRequestReport) SetRequests(requests []BlockedRequest) {
+	r.Spec.Requests = requests
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=cblockr,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterBlockedRequestReport is the Schema for the ClusterBlockedRequestReports API
+type ClusterBlockedRequestReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BlockedRequestReportSpec `json:"spec"`
+}
+
+func (r *ClusterBlockedRequestReport) GetRequests() []BlockedRequest {
+	return r.Spec.Requests
+}
+
+func (r *ClusterBlockedRequestReport) SetRequests(requests []BlockedRequest) {
+	r.Spec.Requests = requests
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BlockedRequestReportList contains a list of BlockedRequestReport
+type BlockedRequestReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlockedRequestReport `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterBlockedRequestReportList contains a list of ClusterBlockedRequestReport
+type ClusterBlockedRequestReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBlockedRequestReport `json:"items"`
+}
@@ -54,10 +54,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AdmissionReportList{},
 		&BackgroundScanReport{},
 		&BackgroundScanReportList{},
+		&BlockedRequestReport{},
+		&BlockedRequestReportList{},
 		&ClusterAdmissionReport{},
 		&ClusterAdmissionReportList{},
 		&ClusterBackgroundScanReport{},
 		&ClusterBackgroundScanReportList{},
+		&ClusterBlockedRequestReport{},
+		&ClusterBlockedRequestReportList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -710,3 +711,39 @@ func Test_doesMatchExcludeConflict(t *testing.T) {
 		})
 	}
 }
+
+func Test_CleanupPolicy_NextExecutionTime(t *testing.T) {
+	spec := CleanupPolicySpec{
+		Schedule: "0 * * * *",
+	}
+	next, err := spec.GetNextExecutionTime(time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC))
+	assert.NilError(t, err)
+	assert.Equal(t, next, time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC))
+}
+
+func Test_CleanupPolicyStatus_AddFailures(t *testing.T) {
+	var status CleanupPolicyStatus
+	for i := 0; i < MaxRecentFailures; i++ {
+		status.AddFailures(ExecutionFailure{Message: fmt.Sprint(i)})
+	}
+	status.AddFailures(ExecutionFailure{Message: "a"}, ExecutionFailure{Message: "b"})
+	assert.Equal(t, len(status.RecentFailures), MaxRecentFailures)
+	assert.Equal(t, status.RecentFailures[0].Message, "b")
+	assert.Equal(t, status.RecentFailures[1].Message, "a")
+	assert.Equal(t, status.RecentFailures[2].Message, fmt.Sprint(MaxRecentFailures-1))
+}
+
+func Test_CleanupPolicy_Safeguards(t *testing.T) {
+	maxDeletions := -1
+	deletionsPerSecond := 0
+	subject := Safeguards{
+		MaxDeletions:       &maxDeletions,
+		DeletionsPerSecond: &deletionsPerSecond,
+		MinAge:             &metav1.Duration{Duration: -time.Hour},
+	}
+	errs := subject.Validate(field.NewPath("spec").Child("safeguards"))
+	assert.Assert(t, len(errs) == 3)
+	assert.Equal(t, errs[0].Field, "spec.safeguards.maxDeletions")
+	assert.Equal(t, errs[1].Field, "spec.safeguards.deletionsPerSecond")
+	assert.Equal(t, errs[2].Field, "spec.safeguards.minAge")
+}
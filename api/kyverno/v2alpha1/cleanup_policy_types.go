@@ -18,6 +18,7 @@ package v2alpha1
 
 import (
 	"reflect"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
@@ -149,6 +150,11 @@ type ClusterCleanupPolicyList struct {
 // CleanupPolicySpec stores specifications for selecting resources that the user needs to delete
 // and schedule when the matching resources needs deleted.
 type CleanupPolicySpec struct {
+	// Context defines variables and data sources that can be used in conditions,
+	// they are evaluated for every resource matched by the policy.
+	// +optional
+	Context []kyvernov1.ContextEntry `json:"context,omitempty"`
+
 	// MatchResources defines when cleanuppolicy should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -167,11 +173,148 @@ type CleanupPolicySpec struct {
 	// Conditions defines conditions used to select resources which user needs to delete
 	// +optional
 	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// DeletionPropagationPolicy defines how the dependents of deleted resources are deleted.
+	// It defaults to the propagation policy of the deleted resource kind.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// Safeguards limits the deletions performed by the policy.
+	// +optional
+	Safeguards *Safeguards `json:"safeguards,omitempty"`
+
+	// DryRun, when set, evaluates the policy without deleting anything.
+	// The resources that would have been deleted are recorded in the policy status.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
+// Safeguards defines limits protecting against unexpected mass deletions.
+type Safeguards struct {
+	// MaxDeletions is the maximum number of resources deleted in a single execution.
+	// When more resources match, the execution is aborted and no resource is deleted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletions *int `json:"maxDeletions,omitempty"`
+
+	// DeletionsPerSecond limits the rate at which resources are deleted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DeletionsPerSecond *int `json:"deletionsPerSecond,omitempty"`
+
+	// MinAge prevents deleting resources created less than MinAge ago.
+	// +optional
+	MinAge *metav1.Duration `json:"minAge,omitempty"`
+}
+
+// Safeguard names, as recorded in the execution status.
+const (
+	SafeguardMaxDeletions       = "maxDeletions"
+	SafeguardDeletionsPerSecond = "deletionsPerSecond"
+	SafeguardMinAge             = "minAge"
+)
+
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// LastExecution contains the result of the last execution.
+	// +optional
+	LastExecution *ExecutionStatus `json:"lastExecution,omitempty"`
+
+	// NextExecutionTime is the next time the policy is scheduled to run.
+	// +optional
+	NextExecutionTime *metav1.Time `json:"nextExecutionTime,omitempty"`
+
+	// RecentFailures lists the most recent failures, newest first, limited to the last 10.
+	// +optional
+	RecentFailures []ExecutionFailure `json:"recentFailures,omitempty"`
+
+	// DryRun contains the result of the last dry run execution.
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+}
+
+// MaxRecentFailures is the maximum number of failures kept in the policy status.
+const MaxRecentFailures = 10
+
+// AddFailures prepends failures to the recent failures, keeping the most recent ones.
+func (s *CleanupPolicyStatus) AddFailures(failures ...ExecutionFailure) {
+	recent := make([]ExecutionFailure, 0, len(failures)+len(s.RecentFailures))
+	for i := len(failures) - 1; i >= 0; i-- {
+		recent = append(recent, failures[i])
+	}
+	recent = append(recent, s.RecentFailures...)
+	if len(recent) > MaxRecentFailures {
+		recent = recent[:MaxRecentFailures]
+	}
+	s.RecentFailures = recent
+}
+
+// ExecutionStatus stores the result of a policy execution.
+type ExecutionStatus struct {
+	// ExecutionTime is the time the execution started.
+	ExecutionTime metav1.Time `json:"executionTime"`
+
+	// Matched is the number of resources matched by the policy.
+	Matched int `json:"matched"`
+
+	// Deleted is the number of resources deleted.
+	Deleted int `json:"deleted"`
+
+	// Failed is the number of errors encountered.
+	Failed int `json:"failed"`
+
+	// TrippedSafeguards lists the safeguards that limited the execution.
+	// +optional
+	TrippedSafeguards []TrippedSafeguard `json:"trippedSafeguards,omitempty"`
+}
+
+// TrippedSafeguard stores a safeguard that limited an execution.
+type TrippedSafeguard struct {
+	// Name is the name of the safeguard.
+	Name string `json:"name"`
+
+	// Message describes the effect of the safeguard.
+	Message string `json:"message"`
+}
+
+// ExecutionFailure stores an error encountered during a policy execution.
+type ExecutionFailure struct {
+	// Time is the time the failure occurred.
+	Time metav1.Time `json:"time"`
+
+	// Resource is the resource that failed to be processed, if any.
+	// +optional
+	Resource *kyvernov1.ResourceSpec `json:"resource,omitempty"`
+
+	// Message is the error message.
+	Message string `json:"message"`
+}
+
+// MaxDryRunResources is the maximum number of resources listed in the dry run status.
+const MaxDryRunResources = 100
+
+// DryRunStatus stores the resources that would have been deleted by a dry run execution.
+type DryRunStatus struct {
+	// ExecutionTime is the time of the dry run execution.
+	ExecutionTime metav1.Time `json:"executionTime"`
+
+	// Count is the number of resources that would have been deleted.
+	Count int `json:"count"`
+
+	// Resources lists the resources that would have been deleted, limited to the first 100.
+	// +optional
+	Resources []kyvernov1.ResourceSpec `json:"resources,omitempty"`
+}
+
+// AddResource records a resource that would have been deleted.
+func (s *DryRunStatus) AddResource(resource kyvernov1.ResourceSpec) {
+	s.Count++
+	if len(s.Resources) < MaxDryRunResources {
+		s.Resources = append(s.Resources, resource)
+	}
 }
 
 // Validate implements programmatic validation
@@ -182,6 +325,23 @@ func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Str
 		errs = append(errs, p.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	}
 	errs = append(errs, p.ValidateMatchExcludeConflict(path)...)
+	if p.Safeguards != nil {
+		errs = append(errs, p.Safeguards.Validate(path.Child("safeguards"))...)
+	}
+	return errs
+}
+
+// Validate checks the safeguards values
+func (s *Safeguards) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.MaxDeletions != nil && *s.MaxDeletions < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxDeletions"), *s.MaxDeletions, "must be greater than or equal to 0"))
+	}
+	if s.DeletionsPerSecond != nil && *s.DeletionsPerSecond < 1 {
+		errs = append(errs, field.Invalid(path.Child("deletionsPerSecond"), *s.DeletionsPerSecond, "must be greater than or equal to 1"))
+	}
+	if s.MinAge != nil && s.MinAge.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("minAge"), s.MinAge.Duration.String(), "must not be negative"))
+	}
 	return errs
 }
 
@@ -193,6 +353,15 @@ func ValidateSchedule(path *field.Path, schedule string) (errs field.ErrorList)
 	return errs
 }
 
+// GetNextExecutionTime returns the next time the schedule fires after the given time.
+func (spec *CleanupPolicySpec) GetNextExecutionTime(after time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
 // ValidateMatchExcludeConflict checks if the resultant of match and exclude block is not an empty set
 func (spec *CleanupPolicySpec) ValidateMatchExcludeConflict(path *field.Path) (errs field.ErrorList) {
 	if spec.ExcludeResources == nil || len(spec.ExcludeResources.All) > 0 || len(spec.MatchResources.All) > 0 {
@@ -0,0 +1,122 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_PolicyExceptionSpec_IsActive(t *testing.T) {
+	now := time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+	at := metav1.NewTime(now)
+	tests := []struct {
+		name        string
+		notBefore   *metav1.Time
+		expiresAt   *metav1.Time
+		wantActive  bool
+		wantExpired bool
+	}{{
+		name:       "no window",
+		wantActive: true,
+	}, {
+		name:       "started",
+		notBefore:  &before,
+		wantActive: true,
+	}, {
+		name:      "not started",
+		notBefore: &after,
+	}, {
+		name:       "not expired",
+		expiresAt:  &after,
+		wantActive: true,
+	}, {
+		name:        "expired",
+		expiresAt:   &before,
+		wantExpired: true,
+	}, {
+		name:        "expires now",
+		expiresAt:   &at,
+		wantExpired: true,
+	}, {
+		name:       "within window",
+		notBefore:  &before,
+		expiresAt:  &after,
+		wantActive: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := PolicyExceptionSpec{NotBefore: tt.notBefore, ExpiresAt: tt.expiresAt}
+			assert.Equal(t, spec.IsActive(now), tt.wantActive)
+			assert.Equal(t, spec.IsExpired(now), tt.wantExpired)
+		})
+	}
+}
+
+func Test_PolicyExceptionSpec_ValidateWindow(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 15, 12, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	spec := PolicyExceptionSpec{NotBefore: &now, ExpiresAt: &earlier}
+	errs := spec.Validate(field.NewPath("spec"))
+	var found bool
+	for _, err := range errs {
+		if err.Field == "spec.expiresAt" {
+			found = true
+			assert.Equal(t, err.Type, field.ErrorTypeInvalid)
+		}
+	}
+	assert.Assert(t, found)
+}
+
+func Test_PolicyExceptionSpec_PodSecurity(t *testing.T) {
+	spec := PolicyExceptionSpec{
+		Exceptions: []Exception{{
+			PolicyName: "disallow-host-path",
+			RuleNames:  []string{"host-path"},
+		}, {
+			PolicyName: "psa",
+			RuleNames:  []string{"baseline"},
+			PodSecurity: []kyvernov1.PodSecurityStandard{{
+				ControlName: "HostPath Volumes",
+			}},
+		}},
+	}
+	assert.Assert(t, spec.ExcludesRule("disallow-host-path", "host-path"))
+	assert.Assert(t, !spec.ExcludesRule("psa", "baseline"))
+	assert.Equal(t, len(spec.PodSecurityExclusions("psa", "baseline")), 1)
+	assert.Equal(t, len(spec.PodSecurityExclusions("disallow-host-path", "host-path")), 0)
+}
+
+func Test_Exception_ValidatePodSecurity(t *testing.T) {
+	tests := []struct {
+		name    string
+		control kyvernov1.PodSecurityStandard
+		wantErr bool
+	}{{
+		name:    "pod level control",
+		control: kyvernov1.PodSecurityStandard{ControlName: "HostPath Volumes"},
+	}, {
+		name:    "pod level control with images",
+		control: kyvernov1.PodSecurityStandard{ControlName: "HostPath Volumes", Images: []string{"nginx"}},
+		wantErr: true,
+	}, {
+		name:    "container level control",
+		control: kyvernov1.PodSecurityStandard{ControlName: "Privileged Containers", Images: []string{"nginx"}},
+	}, {
+		name:    "container level control without images",
+		control: kyvernov1.PodSecurityStandard{ControlName: "Privileged Containers"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exception := Exception{PolicyName: "psa", PodSecurity: []kyvernov1.PodSecurityStandard{tt.control}}
+			errs := exception.Validate(field.NewPath("exceptions").Index(0))
+			assert.Equal(t, len(errs) != 0, tt.wantErr)
+		})
+	}
+}
@@ -16,7 +16,11 @@ limitations under the License.
 package v2alpha1
 
 import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
 	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -49,6 +53,16 @@ func (p *PolicyException) Contains(policy string, rule string) bool {
 	return p.Spec.Contains(policy, rule)
 }
 
+// IsActive returns true if the exception applies at the given time
+func (p *PolicyException) IsActive(now time.Time) bool {
+	return p.Spec.IsActive(now)
+}
+
+// IsExpired returns true if the exception expired at the given time
+func (p *PolicyException) IsExpired(now time.Time) bool {
+	return p.Spec.IsExpired(now)
+}
+
 // PolicyExceptionSpec stores policy exception spec
 type PolicyExceptionSpec struct {
 	// Match defines match clause used to check if a resource applies to the exception
@@ -56,11 +70,29 @@ type PolicyExceptionSpec struct {
 
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions"`
+
+	// Conditions are used to determine if the exception applies to a resource matched by Match.
+	// They are evaluated with the same JSON context as the policy rules.
+	// +optional
+	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// NotBefore is the time from which the exception applies.
+	// The exception applies immediately if not set.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// ExpiresAt is the time from which the exception no longer applies.
+	// The exception never expires if not set.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 // Validate implements programmatic validation
 func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList) {
 	errs = append(errs, p.Match.Validate(path.Child("match"), false, nil)...)
+	if p.NotBefore != nil && p.ExpiresAt != nil && !p.ExpiresAt.After(p.NotBefore.Time) {
+		errs = append(errs, field.Invalid(path.Child("expiresAt"), p.ExpiresAt, "expiresAt must be after notBefore"))
+	}
 	exceptionsPath := path.Child("exceptions")
 	for i, e := range p.Exceptions {
 		errs = append(errs, e.Validate(exceptionsPath.Index(i))...)
@@ -78,6 +110,40 @@ func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
 	return false
 }
 
+// ExcludesRule returns true if it contains an exception excluding the whole policy/rule pair
+func (p *PolicyExceptionSpec) ExcludesRule(policy string, rule string) bool {
+	for _, exception := range p.Exceptions {
+		if exception.Contains(policy, rule) && !exception.HasPodSecurity() {
+			return true
+		}
+	}
+	return false
+}
+
+// PodSecurityExclusions returns the pod security controls excluded for the given policy/rule pair
+func (p *PolicyExceptionSpec) PodSecurityExclusions(policy string, rule string) []kyvernov1.PodSecurityStandard {
+	var exclusions []kyvernov1.PodSecurityStandard
+	for _, exception := range p.Exceptions {
+		if exception.Contains(policy, rule) {
+			exclusions = append(exclusions, exception.PodSecurity...)
+		}
+	}
+	return exclusions
+}
+
+// IsActive returns true if the given time is within the exception validity window
+func (p *PolicyExceptionSpec) IsActive(now time.Time) bool {
+	if p.NotBefore != nil && now.Before(p.NotBefore.Time) {
+		return false
+	}
+	return !p.IsExpired(now)
+}
+
+// IsExpired returns true if the exception has an expiry and it is passed at the given time
+func (p *PolicyExceptionSpec) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(p.ExpiresAt.Time)
+}
+
 // Exception stores infos about a policy and rules
 type Exception struct {
 	// PolicyName identifies the policy to which the exception is applied.
@@ -85,6 +151,11 @@ type Exception struct {
 
 	// RuleNames identifies the rules to which the exception is applied.
 	RuleNames []string `json:"ruleNames"`
+
+	// PodSecurity specifies the Pod Security Standard controls to be excluded from podSecurity rules.
+	// When set, only these controls are exempted and the rules are still applied.
+	// +optional
+	PodSecurity []kyvernov1.PodSecurityStandard `json:"podSecurity,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -92,9 +163,27 @@ func (p *Exception) Validate(path *field.Path) (errs field.ErrorList) {
 	if p.PolicyName == "" {
 		errs = append(errs, field.Required(path.Child("policyName"), "An exception requires a policy name"))
 	}
+	for i, control := range p.PodSecurity {
+		controlPath := path.Child("podSecurity").Index(i)
+		// container level control must specify images
+		if slices.Contains(pssutils.PSS_container_level_control, control.ControlName) {
+			if len(control.Images) == 0 {
+				errs = append(errs, field.Invalid(controlPath.Child("controlName"), control.ControlName, "images must be specified for the container level control"))
+			}
+		} else if slices.Contains(pssutils.PSS_pod_level_control, control.ControlName) {
+			if len(control.Images) != 0 {
+				errs = append(errs, field.Invalid(controlPath.Child("controlName"), control.ControlName, "images must not be specified for the pod level control"))
+			}
+		}
+	}
 	return errs
 }
 
+// HasPodSecurity returns true if the exception is restricted to pod security controls
+func (p *Exception) HasPodSecurity() bool {
+	return len(p.PodSecurity) != 0
+}
+
 // Contains returns true if it contains an exception for the given policy/rule pair
 func (p *Exception) Contains(policy string, rule string) bool {
 	return p.PolicyName == policy && slices.Contains(p.RuleNames, rule)
@@ -22,8 +22,9 @@ limitations under the License.
 package v2alpha1
 
 import (
+	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v2beta1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -89,6 +90,13 @@ func (in *CleanupPolicyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupPolicySpec) DeepCopyInto(out *CleanupPolicySpec) {
 	*out = *in
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = make([]v1.ContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.MatchResources.DeepCopyInto(&out.MatchResources)
 	if in.ExcludeResources != nil {
 		in, out := &in.ExcludeResources, &out.ExcludeResources
@@ -100,6 +108,16 @@ func (in *CleanupPolicySpec) DeepCopyInto(out *CleanupPolicySpec) {
 		*out = new(v2beta1.AnyAllConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeletionPropagationPolicy != nil {
+		in, out := &in.DeletionPropagationPolicy, &out.DeletionPropagationPolicy
+		*out = new(metav1.DeletionPropagation)
+		**out = **in
+	}
+	if in.Safeguards != nil {
+		in, out := &in.Safeguards, &out.Safeguards
+		*out = new(Safeguards)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicySpec.
@@ -117,11 +135,32 @@ func (in *CleanupPolicyStatus) DeepCopyInto(out *CleanupPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastExecution != nil {
+		in, out := &in.LastExecution, &out.LastExecution
+		*out = new(ExecutionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextExecutionTime != nil {
+		in, out := &in.NextExecutionTime, &out.NextExecutionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecentFailures != nil {
+		in, out := &in.RecentFailures, &out.RecentFailures
+		*out = make([]ExecutionFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPolicyStatus.
@@ -193,6 +232,27 @@ func (in *ClusterCleanupPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	in.ExecutionTime.DeepCopyInto(&out.ExecutionTime)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]v1.ResourceSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Exception) DeepCopyInto(out *Exception) {
 	*out = *in
@@ -201,6 +261,13 @@ func (in *Exception) DeepCopyInto(out *Exception) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = make([]v1.PodSecurityStandard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exception.
@@ -213,6 +280,48 @@ func (in *Exception) DeepCopy() *Exception {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionFailure) DeepCopyInto(out *ExecutionFailure) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(v1.ResourceSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionFailure.
+func (in *ExecutionFailure) DeepCopy() *ExecutionFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionStatus) DeepCopyInto(out *ExecutionStatus) {
+	*out = *in
+	in.ExecutionTime.DeepCopyInto(&out.ExecutionTime)
+	if in.TrippedSafeguards != nil {
+		in, out := &in.TrippedSafeguards, &out.TrippedSafeguards
+		*out = make([]TrippedSafeguard, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionStatus.
+func (in *ExecutionStatus) DeepCopy() *ExecutionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyException) DeepCopyInto(out *PolicyException) {
 	*out = *in
@@ -282,6 +391,19 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = new(v2beta1.AnyAllConditions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionSpec.
@@ -293,3 +415,48 @@ func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Safeguards) DeepCopyInto(out *Safeguards) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int)
+		**out = **in
+	}
+	if in.DeletionsPerSecond != nil {
+		in, out := &in.DeletionsPerSecond, &out.DeletionsPerSecond
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinAge != nil {
+		in, out := &in.MinAge, &out.MinAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Safeguards.
+func (in *Safeguards) DeepCopy() *Safeguards {
+	if in == nil {
+		return nil
+	}
+	out := new(Safeguards)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrippedSafeguard) DeepCopyInto(out *TrippedSafeguard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrippedSafeguard.
+func (in *TrippedSafeguard) DeepCopy() *TrippedSafeguard {
+	if in == nil {
+		return nil
+	}
+	out := new(TrippedSafeguard)
+	in.DeepCopyInto(out)
+	return out
+}
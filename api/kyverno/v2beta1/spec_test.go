@@ -2,10 +2,12 @@ package v2beta1
 
 import (
 	"testing"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -53,3 +55,33 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_BackgroundScanSchedule(t *testing.T) {
+	maxResourcesPerSecond := int32(0)
+	subject := Spec{
+		BackgroundScanSchedule: &kyvernov1.BackgroundScanSchedule{
+			Interval:              &metav1.Duration{Duration: 30 * time.Second},
+			MaxResourcesPerSecond: &maxResourcesPerSecond,
+			Priority:              5,
+		},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, nil)
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.backgroundScanSchedule.interval")
+	assert.Equal(t, errs[1].Field, "dummy.backgroundScanSchedule.maxResourcesPerSecond")
+	subject.BackgroundScanSchedule.Interval.Duration = time.Hour
+	maxResourcesPerSecond = 10
+	assert.Equal(t, len(subject.Validate(path, false, nil)), 0)
+	assert.Equal(t, subject.GetBackgroundScanInterval(), time.Hour)
+	assert.Equal(t, subject.GetBackgroundScanMaxResourcesPerSecond(), int32(10))
+	assert.Equal(t, subject.GetBackgroundScanPriority(), int32(5))
+	subject.BackgroundScanSchedule.Interval = nil
+	subject.BackgroundScanSchedule.Schedule = "invalid"
+	errs = subject.Validate(path, false, nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.backgroundScanSchedule.schedule")
+	subject.BackgroundScanSchedule.Schedule = "*/5 * * * *"
+	assert.Equal(t, len(subject.Validate(path, false, nil)), 0)
+	assert.Equal(t, subject.GetBackgroundScanSchedule(), "*/5 * * * *")
+}
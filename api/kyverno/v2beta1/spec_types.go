@@ -2,6 +2,7 @@ package v2beta1
 
 import (
 	"fmt"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -68,6 +69,24 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	GenerateExistingOnPolicyUpdate bool `json:"generateExistingOnPolicyUpdate,omitempty" yaml:"generateExistingOnPolicyUpdate,omitempty"`
+
+	// Order controls the position of this policy when mutate policies are applied to a resource.
+	// Policies with a lower order are applied first, policies with the same order are applied
+	// sorted by name. Each policy is applied to the resource patched by the previous ones.
+	// Defaults to 0.
+	// +optional
+	Order int32 `json:"order,omitempty" yaml:"order,omitempty"`
+
+	// AnnotateMutations controls if resources mutated by this policy are annotated with the
+	// policy rules that changed them and the top-level paths they changed.
+	// Defaults to "false" if not specified.
+	// +optional
+	AnnotateMutations bool `json:"annotateMutations,omitempty" yaml:"annotateMutations,omitempty"`
+
+	// BackgroundScanSchedule controls how existing resources are scanned against the policy
+	// during background scans. Only used when background processing is enabled.
+	// +optional
+	BackgroundScanSchedule *kyvernov1.BackgroundScanSchedule `json:"backgroundScanSchedule,omitempty" yaml:"backgroundScanSchedule,omitempty"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
@@ -179,6 +198,48 @@ func (s *Spec) IsGenerateExistingOnPolicyUpdate() bool {
 	return s.GenerateExistingOnPolicyUpdate
 }
 
+// IsAnnotateMutations returns AnnotateMutations set value
+func (s *Spec) IsAnnotateMutations() bool {
+	return s.AnnotateMutations
+}
+
+// GetOrder returns the order in which mutate policies are applied
+func (s *Spec) GetOrder() int32 {
+	return s.Order
+}
+
+// GetBackgroundScanInterval returns the period of background rescans, zero if periodic rescans are disabled
+func (s *Spec) GetBackgroundScanInterval() time.Duration {
+	if s.BackgroundScanSchedule == nil || s.BackgroundScanSchedule.Interval == nil {
+		return 0
+	}
+	return s.BackgroundScanSchedule.Interval.Duration
+}
+
+// GetBackgroundScanSchedule returns the Cron schedule of background rescans, empty if not specified
+func (s *Spec) GetBackgroundScanSchedule() string {
+	if s.BackgroundScanSchedule == nil {
+		return ""
+	}
+	return s.BackgroundScanSchedule.Schedule
+}
+
+// GetBackgroundScanMaxResourcesPerSecond returns the background scan rate limit, zero if scans are not throttled
+func (s *Spec) GetBackgroundScanMaxResourcesPerSecond() int32 {
+	if s.BackgroundScanSchedule == nil || s.BackgroundScanSchedule.MaxResourcesPerSecond == nil {
+		return 0
+	}
+	return *s.BackgroundScanSchedule.MaxResourcesPerSecond
+}
+
+// GetBackgroundScanPriority returns the background scan priority
+func (s *Spec) GetBackgroundScanPriority() int32 {
+	if s.BackgroundScanSchedule == nil {
+		return 0
+	}
+	return s.BackgroundScanSchedule.Priority
+}
+
 // GetFailurePolicy returns the failure policy to be applied
 func (s *Spec) GetFailurePolicy() kyvernov1.FailurePolicyType {
 	if s.FailurePolicy == nil {
@@ -223,5 +284,8 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, clusterResources sets
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	if s.BackgroundScanSchedule != nil {
+		errs = append(errs, s.BackgroundScanSchedule.Validate(path.Child("backgroundScanSchedule"))...)
+	}
 	return errs
 }
@@ -464,6 +464,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.BackgroundScanSchedule != nil {
+		in, out := &in.BackgroundScanSchedule, &out.BackgroundScanSchedule
+		*out = new(v1.BackgroundScanSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spec.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/cleanup"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -32,8 +33,9 @@ const (
 )
 
 var (
-	none = admissionregistrationv1.SideEffectClassNone
-	fail = admissionregistrationv1.Fail
+	none   = admissionregistrationv1.SideEffectClassNone
+	fail   = admissionregistrationv1.Fail
+	ignore = admissionregistrationv1.Ignore
 )
 
 var logger = logging.ControllerLogger(ControllerName)
@@ -167,6 +169,30 @@ func (c *controller) build(caBundle []byte) (*admissionregistrationv1.Validating
 				FailurePolicy:           &fail,
 				SideEffects:             &none,
 				AdmissionReviewVersions: []string{"v1"},
+			}, {
+				Name:         fmt.Sprintf("ttl.%s.%s.svc", config.KyvernoServiceName(), config.KyvernoNamespace()),
+				ClientConfig: c.clientConfig(caBundle, ttlWebhookServicePath),
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{"*"},
+						APIVersions: []string{"*"},
+						Resources:   []string{"*"},
+					},
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+				}},
+				// only resources with a TTL label are sent to the webhook
+				ObjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      cleanup.TTLLabel,
+						Operator: metav1.LabelSelectorOpExists,
+					}},
+				},
+				FailurePolicy:           &ignore,
+				SideEffects:             &none,
+				AdmissionReviewVersions: []string{"v1"},
 			}},
 		},
 		nil
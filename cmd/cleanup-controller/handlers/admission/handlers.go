@@ -2,13 +2,16 @@ package admission
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cleanup"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	validation "github.com/kyverno/kyverno/pkg/validation/cleanuppolicy"
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type handlers struct {
@@ -33,3 +36,17 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 	}
 	return nil
 }
+
+// ValidateTTL rejects resources with an invalid TTL label
+func (h *handlers) ValidateTTL(_ context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, _ time.Time) *admissionv1.AdmissionResponse {
+	var metadata metav1.PartialObjectMetadata
+	if err := json.Unmarshal(request.Object.Raw, &metadata); err != nil {
+		logger.Error(err, "failed to unmarshal resource from admission request")
+		return admissionutils.Response(request.UID, err)
+	}
+	if _, _, err := cleanup.GetExpiry(&metadata); err != nil {
+		logger.Error(err, "invalid TTL label")
+		return admissionutils.Response(request.UID, err)
+	}
+	return nil
+}
@@ -2,39 +2,55 @@ package cleanup
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
-	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
-	match "github.com/kyverno/kyverno/pkg/utils/match"
 	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
 type handlers struct {
-	client     dclient.Interface
-	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
-	polLister  kyvernov2alpha1listers.CleanupPolicyLister
-	nsLister   corev1listers.NamespaceLister
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	cpolLister    kyvernov2alpha1listers.ClusterCleanupPolicyLister
+	polLister     kyvernov2alpha1listers.CleanupPolicyLister
+	nsLister      corev1listers.NamespaceLister
+	cmResolver    resolvers.ConfigmapResolver
+	metrics       metrics.MetricsConfigManager
 }
 
 func New(
 	client dclient.Interface,
+	kyvernoClient versioned.Interface,
 	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister,
 	polLister kyvernov2alpha1listers.CleanupPolicyLister,
 	nsLister corev1listers.NamespaceLister,
+	cmResolver resolvers.ConfigmapResolver,
+	metricsConfig metrics.MetricsConfigManager,
 ) *handlers {
 	return &handlers{
-		client:     client,
-		cpolLister: cpolLister,
-		polLister:  polLister,
-		nsLister:   nsLister,
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolLister,
+		polLister:     polLister,
+		nsLister:      nsLister,
+		cmResolver:    cmResolver,
+		metrics:       metricsConfig,
 	}
 }
 
@@ -64,14 +80,33 @@ func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy
 	spec := policy.GetSpec()
 	kinds := sets.NewString(spec.MatchResources.GetKinds()...)
 	debug := logger.V(5)
+	execution := kyvernov2alpha1.ExecutionStatus{ExecutionTime: metav1.Now()}
+	var dryRun *kyvernov2alpha1.DryRunStatus
+	if spec.DryRun {
+		dryRun = &kyvernov2alpha1.DryRunStatus{ExecutionTime: execution.ExecutionTime}
+	}
+	var failures []kyvernov2alpha1.ExecutionFailure
 	var errs []error
+	fail := func(resource *kyvernov1.ResourceSpec, err error) {
+		execution.Failed++
+		failures = append(failures, kyvernov2alpha1.ExecutionFailure{
+			Time:     metav1.Now(),
+			Resource: resource,
+			Message:  err.Error(),
+		})
+		errs = append(errs, err)
+	}
+	h.recordExecution(ctx, policy)
+	now := execution.ExecutionTime.Time
+	var tooYoung int
+	var matches []unstructured.Unstructured
 	for kind := range kinds {
 		debug := debug.WithValues("kind", kind)
 		debug.Info("processing...")
 		list, err := h.client.ListResource(ctx, "", kind, policy.GetNamespace(), nil)
 		if err != nil {
 			debug.Error(err, "failed to list resources")
-			errs = append(errs, err)
+			fail(&kyvernov1.ResourceSpec{Kind: kind, Namespace: policy.GetNamespace()}, err)
 		} else {
 			for i := range list.Items {
 				resource := list.Items[i]
@@ -84,68 +119,144 @@ func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy
 						ns, err := h.nsLister.Get(namespace)
 						if err != nil {
 							debug.Error(err, "failed to get namespace labels")
-							errs = append(errs, err)
+							fail(toResourceSpec(resource), err)
+							continue
 						}
 						nsLabels = ns.GetLabels()
 					}
-					// match namespaces
-					if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
-						debug.Info("resource namespace didn't match policy namespace", "result", err)
-					}
-					// match resource with match/exclude clause
-					matched := match.CheckMatchesResources(resource, spec.MatchResources, nsLabels)
-					if matched != nil {
-						debug.Info("resource/match didn't match", "result", matched)
+					matched, err := cleanup.Matches(ctx, logger, h.client, h.cmResolver, policy, resource, nsLabels)
+					if err != nil {
+						debug.Error(err, "failed to match resource")
+						fail(toResourceSpec(resource), err)
 						continue
 					}
-					if spec.ExcludeResources != nil {
-						excluded := match.CheckMatchesResources(resource, *spec.ExcludeResources, nsLabels)
-						if excluded == nil {
-							debug.Info("resource/exclude matched")
-							continue
-						} else {
-							debug.Info("resource/exclude didn't match", "result", excluded)
-						}
-					}
-					// check conditions
-					if spec.Conditions != nil {
-						enginectx := enginecontext.NewContext()
-						if err := enginectx.AddResource(resource.Object); err != nil {
-							debug.Error(err, "failed to add resource in context")
-							errs = append(errs, err)
-							continue
-						}
-						if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
-							debug.Error(err, "failed to add namespace in context")
-							errs = append(errs, err)
-							continue
-						}
-						if err := enginectx.AddImageInfos(&resource); err != nil {
-							debug.Error(err, "failed to add image infos in context")
-							errs = append(errs, err)
-							continue
-						}
-						passed, err := checkAnyAllConditions(logger, enginectx, *spec.Conditions)
-						if err != nil {
-							debug.Error(err, "failed to check condition")
-							errs = append(errs, err)
-							continue
-						}
-						if !passed {
-							debug.Info("conditions did not pass")
-							continue
-						}
+					if !matched {
+						continue
 					}
-					debug.Info("resource matched, it will be deleted...")
-					if err := h.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, false); err != nil {
-						debug.Error(err, "failed to delete resource")
-						errs = append(errs, err)
-					} else {
-						debug.Info("deleted")
+					if isYoungerThan(resource, spec.Safeguards, now) {
+						debug.Info("resource matched but is too young to be deleted")
+						tooYoung++
+						continue
 					}
+					execution.Matched++
+					matches = append(matches, resource)
 				}
 			}
 		}
 	}
+	if tooYoung > 0 {
+		execution.TrippedSafeguards = append(execution.TrippedSafeguards, kyvernov2alpha1.TrippedSafeguard{
+			Name:    kyvernov2alpha1.SafeguardMinAge,
+			Message: fmt.Sprintf("%d matching resources were skipped because they are younger than %s", tooYoung, spec.Safeguards.MinAge.Duration),
+		})
+	}
+	if safeguard := checkMaxDeletions(len(matches), spec.Safeguards); safeguard != nil {
+		logger.Info("execution aborted, too many resources matched", "matched", len(matches))
+		execution.TrippedSafeguards = append(execution.TrippedSafeguards, *safeguard)
+		// in dry run mode the matched resources are still reported as the ones that would be deleted
+		if dryRun == nil {
+			matches = nil
+		}
+	}
+	// delete the oldest resources first
+	sort.SliceStable(matches, func(i, j int) bool {
+		left, right := matches[i].GetCreationTimestamp(), matches[j].GetCreationTimestamp()
+		return left.Before(&right)
+	})
+	limiter := newDeletionLimiter(spec.Safeguards)
+	var throttled bool
+	for _, resource := range matches {
+		namespace := resource.GetNamespace()
+		name := resource.GetName()
+		debug := debug.WithValues("kind", resource.GetKind(), "name", name, "namespace", namespace)
+		if dryRun != nil {
+			debug.Info("resource matched, it would be deleted (dry run)")
+			dryRun.AddResource(*toResourceSpec(resource))
+			h.recordResource(ctx, policy, resource.GetKind(), namespace, metrics.CleanupDryRun)
+			continue
+		}
+		if limiter != nil {
+			delayed, err := wait(ctx, limiter)
+			if err != nil {
+				fail(toResourceSpec(resource), err)
+				break
+			}
+			throttled = throttled || delayed
+		}
+		debug.Info("resource matched, it will be deleted...")
+		if err := h.client.DeleteResourceWithOptions(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, metav1.DeleteOptions{PropagationPolicy: spec.DeletionPropagationPolicy}); err != nil {
+			debug.Error(err, "failed to delete resource")
+			fail(toResourceSpec(resource), err)
+			h.recordResource(ctx, policy, resource.GetKind(), namespace, metrics.CleanupFailed)
+		} else {
+			debug.Info("deleted")
+			execution.Deleted++
+			h.recordResource(ctx, policy, resource.GetKind(), namespace, metrics.CleanupDeleted)
+		}
+	}
+	if throttled {
+		execution.TrippedSafeguards = append(execution.TrippedSafeguards, kyvernov2alpha1.TrippedSafeguard{
+			Name:    kyvernov2alpha1.SafeguardDeletionsPerSecond,
+			Message: fmt.Sprintf("deletions were throttled to %d per second", *spec.Safeguards.DeletionsPerSecond),
+		})
+	}
+	if err := h.updateStatus(ctx, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.LastExecution = &execution
+		if next, err := spec.GetNextExecutionTime(execution.ExecutionTime.Time); err == nil {
+			status.NextExecutionTime = &metav1.Time{Time: next}
+		}
+		status.AddFailures(failures...)
+		// the dry run status is dropped once the policy leaves dry run mode
+		status.DryRun = dryRun
+	}); err != nil {
+		logger.Error(err, "failed to update policy status")
+		errs = append(errs, err)
+	}
 	return multierr.Combine(errs...)
 }
+
+func (h *handlers) recordExecution(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface) {
+	policyType, policyNamespace := metricsPolicyType(policy)
+	if h.metrics.Config().CheckNamespace(policyNamespace) {
+		h.metrics.RecordCleanupExecutions(ctx, policyType, policyNamespace, policy.GetName())
+	}
+}
+
+func (h *handlers) recordResource(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, kind, namespace string, result metrics.CleanupResult) {
+	policyType, policyNamespace := metricsPolicyType(policy)
+	if h.metrics.Config().CheckNamespace(policyNamespace) {
+		h.metrics.RecordCleanupResources(ctx, policyType, policyNamespace, policy.GetName(), kind, namespace, result)
+	}
+}
+
+func metricsPolicyType(policy kyvernov2alpha1.CleanupPolicyInterface) (metrics.PolicyType, string) {
+	if policy.GetNamespace() == "" {
+		return metrics.Cluster, "-"
+	}
+	return metrics.Namespaced, policy.GetNamespace()
+}
+
+func (h *handlers) updateStatus(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, mutate func(*kyvernov2alpha1.CleanupPolicyStatus)) error {
+	if policy.GetNamespace() == "" {
+		_, err := controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov2alpha1.ClusterCleanupPolicy),
+			h.kyvernoClient.KyvernoV2alpha1().ClusterCleanupPolicies(),
+			func(policy *kyvernov2alpha1.ClusterCleanupPolicy) error {
+				mutate(&policy.Status)
+				return nil
+			},
+		)
+		return err
+	}
+	_, err := controllerutils.UpdateStatus(
+		ctx,
+		policy.(*kyvernov2alpha1.CleanupPolicy),
+		h.kyvernoClient.KyvernoV2alpha1().CleanupPolicies(policy.GetNamespace()),
+		func(policy *kyvernov2alpha1.CleanupPolicy) error {
+			mutate(&policy.Status)
+			return nil
+		},
+	)
+	return err
+}
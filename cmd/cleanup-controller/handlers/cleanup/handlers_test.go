@@ -0,0 +1,107 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newConfigMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+	}
+}
+
+func newHandlers(t *testing.T, policy *kyvernov2alpha1.CleanupPolicy, objects ...runtime.Object) *handlers {
+	scheme := runtime.NewScheme()
+	assert.NilError(t, corev1.AddToScheme(scheme))
+	client, err := dclient.NewFakeClient(scheme, map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}))
+	return &handlers{
+		client:        client,
+		kyvernoClient: fake.NewSimpleClientset(policy),
+		nsLister:      corev1listers.NewNamespaceLister(nsIndexer),
+		metrics:       metrics.NewFakeMetricsConfig(),
+	}
+}
+
+func newCleanupPolicy(dryRun bool, maxDeletions int) *kyvernov2alpha1.CleanupPolicy {
+	return &kyvernov2alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "pol"},
+		Spec: kyvernov2alpha1.CleanupPolicySpec{
+			MatchResources: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				}},
+			},
+			Schedule:   "* * * * *",
+			Safeguards: &kyvernov2alpha1.Safeguards{MaxDeletions: &maxDeletions},
+			DryRun:     dryRun,
+		},
+	}
+}
+
+func getStatus(t *testing.T, h *handlers) kyvernov2alpha1.CleanupPolicyStatus {
+	policy, err := h.kyvernoClient.KyvernoV2alpha1().CleanupPolicies("test").Get(context.TODO(), "pol", metav1.GetOptions{})
+	assert.NilError(t, err)
+	return policy.Status
+}
+
+func Test_executePolicyDryRunMaxDeletions(t *testing.T) {
+	policy := newCleanupPolicy(true, 1)
+	h := newHandlers(t, policy, newConfigMap("a"), newConfigMap("b"))
+	assert.NilError(t, h.executePolicy(context.TODO(), logging.GlobalLogger(), policy))
+	status := getStatus(t, h)
+	// the safeguard is reported and the matched resources are still listed
+	assert.Equal(t, len(status.LastExecution.TrippedSafeguards), 1)
+	assert.Equal(t, status.LastExecution.TrippedSafeguards[0].Name, kyvernov2alpha1.SafeguardMaxDeletions)
+	assert.Equal(t, status.LastExecution.Deleted, 0)
+	assert.Assert(t, status.DryRun != nil)
+	assert.Equal(t, status.DryRun.Count, 2)
+	list, err := h.client.ListResource(context.TODO(), "v1", "ConfigMap", "test", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 2)
+}
+
+func Test_executePolicyMaxDeletions(t *testing.T) {
+	policy := newCleanupPolicy(false, 1)
+	h := newHandlers(t, policy, newConfigMap("a"), newConfigMap("b"))
+	assert.NilError(t, h.executePolicy(context.TODO(), logging.GlobalLogger(), policy))
+	status := getStatus(t, h)
+	assert.Equal(t, len(status.LastExecution.TrippedSafeguards), 1)
+	assert.Equal(t, status.LastExecution.Deleted, 0)
+	assert.Assert(t, status.DryRun == nil)
+	list, err := h.client.ListResource(context.TODO(), "v1", "ConfigMap", "test", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 2)
+}
+
+func Test_executePolicyClearsDryRun(t *testing.T) {
+	policy := newCleanupPolicy(false, 10)
+	policy.Status.DryRun = &kyvernov2alpha1.DryRunStatus{Count: 1}
+	h := newHandlers(t, policy, newConfigMap("a"))
+	assert.NilError(t, h.executePolicy(context.TODO(), logging.GlobalLogger(), policy))
+	status := getStatus(t, h)
+	assert.Assert(t, status.DryRun == nil)
+	assert.Equal(t, status.LastExecution.Deleted, 1)
+}
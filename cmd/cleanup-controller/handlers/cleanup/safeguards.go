@@ -0,0 +1,65 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func toResourceSpec(resource unstructured.Unstructured) *kyvernov1.ResourceSpec {
+	return &kyvernov1.ResourceSpec{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+	}
+}
+
+// isYoungerThan returns true if the resource was created less than the safeguards min age ago
+func isYoungerThan(resource unstructured.Unstructured, safeguards *kyvernov2alpha1.Safeguards, now time.Time) bool {
+	if safeguards == nil || safeguards.MinAge == nil {
+		return false
+	}
+	return now.Sub(resource.GetCreationTimestamp().Time) < safeguards.MinAge.Duration
+}
+
+// checkMaxDeletions returns the tripped safeguard if more resources matched than the safeguards allow to delete
+func checkMaxDeletions(matched int, safeguards *kyvernov2alpha1.Safeguards) *kyvernov2alpha1.TrippedSafeguard {
+	if safeguards == nil || safeguards.MaxDeletions == nil || matched <= *safeguards.MaxDeletions {
+		return nil
+	}
+	return &kyvernov2alpha1.TrippedSafeguard{
+		Name:    kyvernov2alpha1.SafeguardMaxDeletions,
+		Message: fmt.Sprintf("%d resources matched, more than the maximum of %d deletions, no resource was deleted", matched, *safeguards.MaxDeletions),
+	}
+}
+
+func newDeletionLimiter(safeguards *kyvernov2alpha1.Safeguards) *rate.Limiter {
+	if safeguards == nil || safeguards.DeletionsPerSecond == nil {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(*safeguards.DeletionsPerSecond), 1)
+}
+
+// wait blocks until the limiter allows a deletion, it returns true if the deletion was delayed
+func wait(ctx context.Context, limiter *rate.Limiter) (bool, error) {
+	reservation := limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return false, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return true, ctx.Err()
+	}
+}
@@ -0,0 +1,43 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_isYoungerThan(t *testing.T) {
+	now := time.Now()
+	resource := unstructured.Unstructured{}
+	resource.SetCreationTimestamp(metav1.NewTime(now.Add(-30 * time.Minute)))
+	assert.Equal(t, isYoungerThan(resource, nil, now), false)
+	assert.Equal(t, isYoungerThan(resource, &kyvernov2alpha1.Safeguards{MinAge: &metav1.Duration{Duration: time.Hour}}, now), true)
+	assert.Equal(t, isYoungerThan(resource, &kyvernov2alpha1.Safeguards{MinAge: &metav1.Duration{Duration: time.Minute}}, now), false)
+}
+
+func Test_checkMaxDeletions(t *testing.T) {
+	maxDeletions := 2
+	safeguards := &kyvernov2alpha1.Safeguards{MaxDeletions: &maxDeletions}
+	assert.Assert(t, checkMaxDeletions(10, nil) == nil)
+	assert.Assert(t, checkMaxDeletions(2, safeguards) == nil)
+	tripped := checkMaxDeletions(3, safeguards)
+	assert.Assert(t, tripped != nil)
+	assert.Equal(t, tripped.Name, kyvernov2alpha1.SafeguardMaxDeletions)
+}
+
+func Test_deletionLimiter(t *testing.T) {
+	assert.Assert(t, newDeletionLimiter(nil) == nil)
+	deletionsPerSecond := 10
+	limiter := newDeletionLimiter(&kyvernov2alpha1.Safeguards{DeletionsPerSecond: &deletionsPerSecond})
+	delayed, err := wait(context.TODO(), limiter)
+	assert.NilError(t, err)
+	assert.Equal(t, delayed, false)
+	delayed, err = wait(context.TODO(), limiter)
+	assert.NilError(t, err)
+	assert.Equal(t, delayed, true)
+}
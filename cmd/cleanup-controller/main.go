@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"sync"
 	"time"
@@ -15,9 +16,13 @@ import (
 	dynamicclient "github.com/kyverno/kyverno/pkg/clients/dynamic"
 	kubeclient "github.com/kyverno/kyverno/pkg/clients/kube"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
+	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"github.com/kyverno/kyverno/pkg/controllers/exception"
+	"github.com/kyverno/kyverno/pkg/controllers/ttl"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
@@ -27,7 +32,9 @@ import (
 )
 
 const (
-	resyncPeriod = 15 * time.Minute
+	resyncPeriod     = 15 * time.Minute
+	schedulerBuiltin = "builtin"
+	schedulerCronJob = "cronjob"
 )
 
 // TODO:
@@ -49,9 +56,13 @@ func main() {
 	var (
 		leaderElectionRetryPeriod time.Duration
 		dumpPayload               bool
+		scheduler                 string
+		cleanupExpiredExceptions  bool
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&scheduler, "scheduler", schedulerBuiltin, "Set the cleanup policies scheduler, 'builtin' runs policies in process and deletes the CronJobs created by 'cronjob', 'cronjob' creates a CronJob per policy.")
+	flagset.BoolVar(&cleanupExpiredExceptions, "cleanupExpiredExceptions", false, "Set this flag to delete policy exceptions when they expire.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	// config
 	appConfig := internal.NewConfiguration(
@@ -71,10 +82,38 @@ func main() {
 	// setup metrics
 	ctx, logger, metricsConfig, sdown := internal.Setup()
 	defer sdown()
+	if scheduler != schedulerBuiltin && scheduler != schedulerCronJob {
+		logger.Error(fmt.Errorf("invalid scheduler %s", scheduler), "scheduler must be either builtin or cronjob")
+		os.Exit(1)
+	}
 	// create instrumented clients
 	kubeClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	leaderElectionClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	kyvernoClient := internal.CreateKyvernoClient(logger, kyvernoclient.WithMetrics(metricsConfig, metrics.KubeClient), kyvernoclient.WithTracing())
+	dynamicClient := internal.CreateDynamicClient(logger, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
+	metadataClient := internal.CreateMetadataClient(logger, metadataclient.WithMetrics(metricsConfig, metrics.KyvernoClient), metadataclient.WithTracing())
+	dClient := internal.CreateDClient(logger, ctx, dynamicClient, kubeClient, 15*time.Minute)
+	// informer factories
+	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
+	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
+	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	// listers
+	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
+	cpolLister := kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies().Lister()
+	polLister := kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies().Lister()
+	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
+	// start informers and wait for cache sync
+	if !internal.StartInformersAndWaitForCacheSync(ctx, kubeKyvernoInformer, kubeInformer, kyvernoInformer) {
+		os.Exit(1)
+	}
+	cmResolver, err := resolvers.NewClientBasedResolver(kubeClient)
+	if err != nil {
+		logger.Error(err, "failed to create config map resolver")
+		os.Exit(1)
+	}
+	// create handlers
+	admissionHandlers := admissionhandlers.New(dClient)
+	cleanupHandlers := cleanuphandlers.New(dClient, kyvernoClient, cpolLister, polLister, nsLister, cmResolver, metricsConfig)
 	// setup leader election
 	le, err := leaderelection.New(
 		logger.WithName("leader-election"),
@@ -119,17 +158,54 @@ func main() {
 				),
 				Workers,
 			)
-			cleanupController := internal.NewController(
-				cleanup.ControllerName,
-				cleanup.NewController(
-					kubeClient,
-					kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies(),
-					kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies(),
-					kubeInformer.Batch().V1().CronJobs(),
-					"https://"+config.KyvernoServiceName()+"."+config.KyvernoNamespace()+".svc",
+			var cleanupController internal.Controller
+			switch scheduler {
+			case schedulerCronJob:
+				cleanupController = internal.NewController(
+					cleanup.ControllerName,
+					cleanup.NewController(
+						kubeClient,
+						kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies(),
+						kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies(),
+						kubeInformer.Batch().V1().CronJobs(),
+						"https://"+config.KyvernoServiceName()+"."+config.KyvernoNamespace()+".svc",
+					),
+					cleanup.Workers,
+				)
+			case schedulerBuiltin:
+				cleanupController = internal.NewController(
+					cleanup.SchedulerName,
+					cleanup.NewScheduler(
+						kubeClient,
+						kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies(),
+						kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies(),
+						kubeInformer.Batch().V1().CronJobs(),
+						cleanupHandlers.Cleanup,
+					),
+					cleanup.SchedulerWorkers,
+				)
+			}
+			ttlController := internal.NewController(
+				ttl.ControllerName,
+				ttl.NewManager(
+					metadataClient,
+					kubeClient.Discovery(),
+					kubeClient.AuthorizationV1().SelfSubjectRulesReviews(),
+					metricsConfig,
 				),
-				cleanup.Workers,
+				ttl.Workers,
 			)
+			var exceptionController internal.Controller
+			if cleanupExpiredExceptions {
+				exceptionController = internal.NewController(
+					exception.ControllerName,
+					exception.NewController(
+						kyvernoClient,
+						kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions(),
+					),
+					exception.Workers,
+				)
+			}
 			// start informers and wait for cache sync
 			if !internal.StartInformersAndWaitForCacheSync(ctx, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -140,6 +216,10 @@ func main() {
 			certController.Run(ctx, logger, &wg)
 			webhookController.Run(ctx, logger, &wg)
 			cleanupController.Run(ctx, logger, &wg)
+			ttlController.Run(ctx, logger, &wg)
+			if exceptionController != nil {
+				exceptionController.Run(ctx, logger, &wg)
+			}
 			// wait all controllers shut down
 			wg.Wait()
 		},
@@ -149,24 +229,6 @@ func main() {
 		logger.Error(err, "failed to initialize leader election")
 		os.Exit(1)
 	}
-	dynamicClient := internal.CreateDynamicClient(logger, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
-	dClient := internal.CreateDClient(logger, ctx, dynamicClient, kubeClient, 15*time.Minute)
-	// informer factories
-	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
-	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
-	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
-	// listers
-	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
-	cpolLister := kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies().Lister()
-	polLister := kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies().Lister()
-	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
-	// start informers and wait for cache sync
-	if !internal.StartInformersAndWaitForCacheSync(ctx, kubeKyvernoInformer, kubeInformer, kyvernoInformer) {
-		os.Exit(1)
-	}
-	// create handlers
-	admissionHandlers := admissionhandlers.New(dClient)
-	cleanupHandlers := cleanuphandlers.New(dClient, cpolLister, polLister, nsLister)
 	// create server
 	server := NewServer(
 		func() ([]byte, []byte, error) {
@@ -177,6 +239,7 @@ func main() {
 			return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
 		},
 		admissionHandlers.Validate,
+		admissionHandlers.ValidateTTL,
 		cleanupHandlers.Cleanup,
 		metricsConfig,
 		webhooks.DebugModeOptions{
@@ -21,6 +21,8 @@ import (
 const (
 	// validatingWebhookServicePath is the path for validation webhook
 	validatingWebhookServicePath = "/validate"
+	// ttlWebhookServicePath is the path for TTL label validation webhook
+	ttlWebhookServicePath = "/validate-ttl"
 )
 
 type Server interface {
@@ -49,6 +51,7 @@ type Probes interface {
 func NewServer(
 	tlsProvider TlsProvider,
 	validationHandler ValidationHandler,
+	ttlValidationHandler ValidationHandler,
 	cleanupHandler CleanupHandler,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts webhooks.DebugModeOptions,
@@ -56,6 +59,7 @@ func NewServer(
 ) Server {
 	policyLogger := logging.WithName("cleanup-policy")
 	cleanupLogger := logging.WithName("cleanup")
+	ttlLogger := logging.WithName("ttl")
 	cleanupHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
 		policy := r.URL.Query().Get("policy")
 		logger := cleanupLogger.WithValues("policy", policy)
@@ -81,6 +85,16 @@ func NewServer(
 			WithAdmission(policyLogger.WithName("validate")).
 			ToHandlerFunc(),
 	)
+	mux.HandlerFunc(
+		"POST",
+		ttlWebhookServicePath,
+		handlers.FromAdmissionFunc("VALIDATE", ttlValidationHandler).
+			WithDump(debugModeOpts.DumpPayload).
+			WithSubResourceFilter().
+			WithMetrics(ttlLogger, metricsConfig.Config(), metrics.WebhookValidating).
+			WithAdmission(ttlLogger.WithName("validate")).
+			ToHandlerFunc(),
+	)
 	mux.HandlerFunc(
 		"GET",
 		cleanup.CleanupServicePath,
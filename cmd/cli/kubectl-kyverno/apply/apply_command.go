@@ -3,6 +3,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -14,13 +15,18 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	"github.com/kyverno/kyverno/pkg/version"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
@@ -61,10 +67,30 @@ type ApplyCommandConfig struct {
 	Stdin           bool
 	RegistryAccess  bool
 	AuditWarn       bool
+	CheckConflicts  bool
 	ResourcePaths   []string
 	PolicyPaths     []string
 	GitBranch       string
+	OutputFormat    string
+	OutputFile      string
 	warnExitCode    int
+	conflicts       []MutationConflict
+	responses       []output.Response
+	engineResponses []*response.EngineResponse
+	// out receives the human readable output, defaults to stdout
+	out io.Writer
+}
+
+func (c *ApplyCommandConfig) stdout() io.Writer {
+	if c.out == nil {
+		return os.Stdout
+	}
+	return c.out
+}
+
+type MutationConflict struct {
+	Resource string
+	Conflict patch.Conflict
 }
 
 var (
@@ -83,6 +109,12 @@ To apply policies from a gitSourceURL on a cluster:
 	Example: Taking github.com as a gitSourceURL here. Some other standards  gitSourceURL are: gitlab.com , bitbucket.org , etc.
 		kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster
 
+To check for conflicting mutations between policies:
+        kyverno apply /path/to/folderOfPolicies --resource=/path/to/resources/ --check-conflicts
+
+To write the results as SARIF, JUnit XML or JSON:
+        kyverno apply /path/to/folderOfPolicies --resource=/path/to/resources/ --output-format sarif --output-file results.sarif
+
 To apply policy with variables:
 
 	1. To apply single policy with variable on single resource use flag "set".
@@ -167,12 +199,39 @@ func Command() *cobra.Command {
 				}
 			}()
 			applyCommandConfig.PolicyPaths = policyPaths
+			format, err := output.ParseFormat(applyCommandConfig.OutputFormat)
+			if err != nil {
+				return sanitizederror.NewWithError("invalid output format", err)
+			}
+			applyCommandConfig.OutputFormat = string(format)
+			applyCommandConfig.out = output.Summary(format, applyCommandConfig.OutputFile)
+			var writer io.WriteCloser
+			if format != output.Text {
+				if writer, err = output.Open(applyCommandConfig.OutputFile); err != nil {
+					return sanitizederror.NewWithError("failed to open output file", err)
+				}
+			}
 			rc, resources, skipInvalidPolicies, pvInfos, err := applyCommandConfig.applyCommandHelper()
 			if err != nil {
 				return err
 			}
 
-			PrintReportOrViolation(applyCommandConfig.PolicyReport, rc, applyCommandConfig.ResourcePaths, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
+			if applyCommandConfig.CheckConflicts {
+				PrintMutationConflicts(applyCommandConfig.stdout(), applyCommandConfig.conflicts)
+			}
+			if writer != nil {
+				err := output.Write(writer, format, version.BuildVersion, applyCommandConfig.responses, applyCommandConfig.engineResponses)
+				if closeErr := writer.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					return sanitizederror.NewWithError("failed to write results", err)
+				}
+			}
+			PrintReportOrViolation(applyCommandConfig.stdout(), applyCommandConfig.PolicyReport, rc, applyCommandConfig.ResourcePaths, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
+			if len(applyCommandConfig.conflicts) > 0 {
+				osExit(1)
+			}
 			return nil
 		},
 	}
@@ -192,6 +251,9 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
 	cmd.Flags().BoolVarP(&applyCommandConfig.AuditWarn, "audit-warn", "", false, "If set to true, will flag audit policies as warnings instead of failures")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
+	cmd.Flags().BoolVarP(&applyCommandConfig.CheckConflicts, "check-conflicts", "", false, "If set to true, mutate policies are applied in order on each resource and conflicting mutations are reported as failures")
+	cmd.Flags().StringVarP(&applyCommandConfig.OutputFormat, "output-format", "", string(output.Text), "Format of the results, one of text, json (engine responses), sarif or junit; other formats than text are written to stdout or to the output file and the summary is printed to stderr")
+	cmd.Flags().StringVarP(&applyCommandConfig.OutputFile, "output-file", "", "", "File receiving the results when an output format other than text is used")
 	return cmd
 }
 
@@ -255,14 +317,14 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if isGit {
 		gitSourceURL, err := url.Parse(c.PolicyPaths[0])
 		if err != nil {
-			fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+			fmt.Fprintf(c.stdout(), "Error: failed to load policies\nCause: %s\n", err)
 			osExit(1)
 		}
 
 		pathElems := strings.Split(gitSourceURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --git-branch flag) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --git-branch flag)", gitSourceURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(c.stdout(), "Error: failed to parse URL \nCause: %s\n", err)
 			osExit(1)
 		}
 
@@ -272,7 +334,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		c.GitBranch, gitPathToYamls = common.GetGitBranchOrPolicyPaths(c.GitBranch, repoURL, c.PolicyPaths)
 		_, cloneErr := gitutils.Clone(repoURL, fs, c.GitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(c.stdout(), "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			osExit(1)
 		}
@@ -285,7 +347,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	}
 	policies, err = common.GetPoliciesFromPaths(fs, c.PolicyPaths, isGit, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(c.stdout(), "Error: failed to load policies\nCause: %s\n", err)
 		osExit(1)
 	}
 
@@ -322,9 +384,15 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 
 	resources, err = common.GetResourceAccordingToResourcePath(fs, c.ResourcePaths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, false, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(c.stdout(), "Error: failed to load resources\nCause: %s\n", err)
 		osExit(1)
 	}
+	common.ParseCRDs(openApiManager, resources)
+
+	var locations output.Locations
+	if c.OutputFormat != "" && c.OutputFormat != string(output.Text) && !c.Cluster {
+		locations = output.LoadLocations(nil, c.ResourcePaths...)
+	}
 
 	if (len(resources) > 1 || len(policies) > 1) && c.VariablesString != "" {
 		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
@@ -336,7 +404,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if c.UserInfoPath != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, c.UserInfoPath, false, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(c.stdout(), "Error: failed to load request info\nCause: %s\n", err)
 			osExit(1)
 		}
 		store.SetSubjects(subjectInfo)
@@ -372,9 +440,9 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if len(policies) > 0 && len(resources) > 0 {
 		if !c.Stdin {
 			if mutatedPolicyRulesCount > policyRulesCount {
-				fmt.Printf("\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(c.stdout(), "\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			} else {
-				fmt.Printf("\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(c.stdout(), "\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			}
 		}
 	}
@@ -383,6 +451,18 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	skipInvalidPolicies.skipped = make([]string, 0)
 	skipInvalidPolicies.invalid = make([]string, 0)
 
+	// in conflict check mode, policies are applied in admission order on the resources patched by the previous policies
+	var trackers []*patch.ConflictTracker
+	patchedResources := resources
+	if c.CheckConflicts {
+		policies = policyutils.SortByOrder(policies)
+		patchedResources = make([]*unstructured.Unstructured, len(resources))
+		copy(patchedResources, resources)
+		for range resources {
+			trackers = append(trackers, patch.NewConflictTracker())
+		}
+	}
+
 	for _, policy := range policies {
 		_, err := policy2.Validate(policy, nil, true, openApiManager)
 		if err != nil {
@@ -410,7 +490,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 
 		kindOnwhichPolicyIsApplied := common.GetKindsFromPolicy(policy, subresources, dClient)
 
-		for _, resource := range resources {
+		for i, resource := range patchedResources {
 			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
 				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
@@ -430,18 +510,59 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 				Client:               dClient,
 				AuditWarn:            c.AuditWarn,
 				Subresources:         subresources,
+				MergeSchemas:         openApiManager.MergeSchemas(),
+				Out:                  c.stdout(),
 			}
-			_, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
+			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
 				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Errorf("failed to apply policy %v on resource %v", policy.GetName(), resource.GetName()).Error(), err)
 			}
 			pvInfos = append(pvInfos, info)
+			if c.OutputFormat != "" && c.OutputFormat != string(output.Text) {
+				c.responses = append(c.responses, buildResponse(policy, resource, ers, info, locations))
+				c.engineResponses = append(c.engineResponses, ers...)
+			}
+			if c.CheckConflicts && len(ers) > 0 {
+				c.trackConflicts(trackers[i], policy, ers[0])
+				patchedResources[i] = &ers[0].PatchedResource
+			}
 		}
 	}
 
 	return rc, resources, skipInvalidPolicies, pvInfos, nil
 }
 
+// trackConflicts - recording the patches of a mutate engine response and the conflicts they introduce
+func (c *ApplyCommandConfig) trackConflicts(tracker *patch.ConflictTracker, policy kyvernov1.PolicyInterface, mutateResponse *response.EngineResponse) {
+	resource := mutateResponse.PatchedResource
+	resPath := fmt.Sprintf("%s/%s/%s", resource.GetNamespace(), resource.GetKind(), resource.GetName())
+	for _, rule := range mutateResponse.PolicyResponse.Rules {
+		writer := patch.PatchWriter{PolicyNamespace: policy.GetNamespace(), PolicyName: policy.GetName(), RuleName: rule.Name}
+		conflicts, err := tracker.Track(writer, rule.Patches)
+		if err != nil {
+			log.Log.Error(err, "failed to track mutation conflicts", "policy", policy.GetName(), "rule", rule.Name)
+		}
+		for _, conflict := range conflicts {
+			c.conflicts = append(c.conflicts, MutationConflict{Resource: resPath, Conflict: conflict})
+		}
+	}
+}
+
+// PrintMutationConflicts - printing conflicting mutations
+func PrintMutationConflicts(out io.Writer, conflicts []MutationConflict) {
+	divider := "----------------------------------------------------------------------"
+	fmt.Fprintln(out, divider)
+	if len(conflicts) == 0 {
+		fmt.Fprintln(out, "No conflicting mutations found")
+	} else {
+		fmt.Fprintln(out, "Conflicting mutations:")
+		for i, conflict := range conflicts {
+			fmt.Fprintf(out, "%d. %s: %s\n", i+1, conflict.Resource, conflict.Conflict)
+		}
+	}
+	fmt.Fprintln(out, divider)
+}
+
 // checkMutateLogPath - checking path for printing mutated resource (-o flag)
 func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err error) {
 	if mutateLogPath != "" {
@@ -465,42 +586,42 @@ func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err erro
 }
 
 // PrintReportOrViolation - printing policy report/violations
-func PrintReportOrViolation(policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
+func PrintReportOrViolation(out io.Writer, policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
 	divider := "----------------------------------------------------------------------"
 
 	if len(skipInvalidPolicies.skipped) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Policies Skipped (as required variables are not provided by the user):")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Policies Skipped (as required variables are not provided by the user):")
 		for i, policyName := range skipInvalidPolicies.skipped {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 	if len(skipInvalidPolicies.invalid) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Invalid Policies:")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Invalid Policies:")
 		for i, policyName := range skipInvalidPolicies.invalid {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 
 	if policyReport {
 		resps := buildPolicyReports(pvInfos)
 		if len(resps) > 0 || resourcesLen == 0 {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT:")
-			fmt.Println(divider)
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT:")
+			fmt.Fprintln(out, divider)
 			report, _ := generateCLIRaw(resps)
 			yamlReport, _ := yaml1.Marshal(report)
-			fmt.Println(string(yamlReport))
+			fmt.Fprintln(out, string(yamlReport))
 		} else {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
 		}
 	} else {
 		if !stdin {
-			fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
+			fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
 				rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 		}
 	}
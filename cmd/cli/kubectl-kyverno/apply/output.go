@@ -0,0 +1,75 @@
+package apply
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// buildResponse converts the engine responses of a policy applied to a resource, the status of validation
+// rules is taken from the violation info as it accounts for audit warnings and policies that are not scored
+func buildResponse(policy kyvernov1.PolicyInterface, resource *unstructured.Unstructured, ers []*response.EngineResponse, info common.Info, locations output.Locations) output.Response {
+	policyName := policy.GetName()
+	if policy.GetNamespace() != "" {
+		policyName = policy.GetNamespace() + "/" + policyName
+	}
+	resp := output.Response{
+		Policy: policyName,
+		Resource: output.Resource{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Namespace:  resource.GetNamespace(),
+			Name:       resource.GetName(),
+			Location:   locations.Lookup(resource.GetKind(), resource.GetNamespace(), resource.GetName()),
+		},
+		Rules: []output.Rule{},
+	}
+	violatedRules := map[string]kyvernov1.ViolatedRule{}
+	for _, result := range info.Results {
+		for _, rule := range result.Rules {
+			violatedRules[rule.Name] = rule
+		}
+	}
+	seen := map[string]bool{}
+	for _, er := range ers {
+		for _, rule := range er.PolicyResponse.Rules {
+			r := output.Rule{
+				Name:    rule.Name,
+				Type:    string(rule.Type),
+				Status:  ruleStatus(rule.Status),
+				Message: rule.Message,
+			}
+			if rule.Type == response.Validation || rule.Type == response.ImageVerify {
+				if violatedRule, ok := violatedRules[rule.Name]; ok {
+					r.Status = string(violatedRule.Status)
+				}
+				seen[rule.Name] = true
+			}
+			resp.Rules = append(resp.Rules, r)
+		}
+	}
+	// validation rules not processed by the engine are only reported as skipped in the violation info
+	for _, result := range info.Results {
+		for _, rule := range result.Rules {
+			if !seen[rule.Name] {
+				resp.Rules = append(resp.Rules, output.Rule{
+					Name:    rule.Name,
+					Type:    rule.Type,
+					Status:  string(rule.Status),
+					Message: rule.Message,
+				})
+			}
+		}
+	}
+	return resp
+}
+
+func ruleStatus(status response.RuleStatus) string {
+	if status == response.RuleStatusWarn {
+		return policyreportv1alpha2.StatusWarn
+	}
+	return status.String()
+}
@@ -0,0 +1,136 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var cleanupHelp = `
+Lists the resources a cleanup policy would delete, without deleting anything.
+Namespace labels are taken from the Namespace manifests provided with the resources.
+
+	kyverno cleanup /path/to/cleanup-policy.yaml --resource /path/to/resources.yaml
+
+	kyverno cleanup /path/to/policies/ --resource /path/to/resources/
+`
+
+// Command returns cleanup command
+func Command() *cobra.Command {
+	var resourcePaths []string
+	cmd := &cobra.Command{
+		Use:          "cleanup",
+		Short:        "Runs cleanup policies against local resources and lists the matches",
+		Example:      cleanupHelp,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, policyPaths []string) error {
+			if len(policyPaths) == 0 {
+				return sanitizederror.NewWithError("require policy", fmt.Errorf("no cleanup policy provided"))
+			}
+			if len(resourcePaths) == 0 {
+				return sanitizederror.NewWithError("require resource", fmt.Errorf("no resource provided"))
+			}
+			policies, err := loadPolicies(policyPaths)
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load cleanup policies", err)
+			}
+			resources, err := common.GetResourceAccordingToResourcePath(nil, resourcePaths, false, nil, nil, "", false, false, "")
+			if err != nil {
+				return sanitizederror.NewWithError("failed to load resources", err)
+			}
+			for _, policy := range policies {
+				matches, err := matchResources(policy, resources)
+				if err != nil {
+					return sanitizederror.NewWithError(fmt.Sprintf("failed to apply cleanup policy %s", policy.GetName()), err)
+				}
+				printMatches(policy, matches)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&resourcePaths, "resource", "r", []string{}, "Path to resource files")
+	return cmd
+}
+
+func loadPolicies(paths []string) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	var policies []kyvernov2alpha1.CleanupPolicyInterface
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		fileDesc, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files := []string{path}
+		if fileDesc.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			files = nil
+			for _, entry := range entries {
+				ext := filepath.Ext(entry.Name())
+				if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+		for _, file := range files {
+			fileBytes, err := os.ReadFile(file) // #nosec G304
+			if err != nil {
+				return nil, err
+			}
+			filePolicies, err := yamlutils.GetCleanupPolicy(fileBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process %s: %w", file, err)
+			}
+			policies = append(policies, filePolicies...)
+		}
+	}
+	return policies, nil
+}
+
+func matchResources(policy kyvernov2alpha1.CleanupPolicyInterface, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	namespaceLabels := map[string]map[string]string{}
+	for _, resource := range resources {
+		if resource.GetKind() == "Namespace" && resource.GetAPIVersion() == "v1" {
+			namespaceLabels[resource.GetName()] = resource.GetLabels()
+		}
+	}
+	logger := log.Log.WithName("cleanup").WithValues("policy", policy.GetName())
+	var matches []*unstructured.Unstructured
+	for _, resource := range resources {
+		matched, err := cleanup.Matches(context.TODO(), logger, nil, nil, policy, *resource, namespaceLabels[resource.GetNamespace()])
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			matches = append(matches, resource)
+		}
+	}
+	return matches, nil
+}
+
+func printMatches(policy kyvernov2alpha1.CleanupPolicyInterface, matches []*unstructured.Unstructured) {
+	name := policy.GetName()
+	if policy.GetNamespace() != "" {
+		name = policy.GetNamespace() + "/" + name
+	}
+	fmt.Printf("\n%s %s would delete %d resource(s)\n", policy.GetKind(), name, len(matches))
+	for _, resource := range matches {
+		if resource.GetNamespace() != "" {
+			fmt.Printf("  %s %s/%s/%s\n", resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName())
+		} else {
+			fmt.Printf("  %s %s/%s\n", resource.GetAPIVersion(), resource.GetKind(), resource.GetName())
+		}
+	}
+}
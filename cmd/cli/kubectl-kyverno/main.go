@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/cleanup"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
@@ -33,6 +34,7 @@ func main() {
 		apply.Command(),
 		test.Command(),
 		jp.Command(),
+		cleanup.Command(),
 	}
 
 	if enableExperimental() {
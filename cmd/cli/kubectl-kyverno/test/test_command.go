@@ -21,6 +21,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/manifest"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/output"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/autogen"
@@ -30,6 +31,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	"github.com/kyverno/kyverno/pkg/version"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
@@ -166,6 +168,7 @@ func Command() *cobra.Command {
 	var testCase string
 	var fileName, gitBranch string
 	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate bool
+	var outputFormat, outputFile string
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -186,8 +189,13 @@ func Command() *cobra.Command {
 			} else if manifestValidate {
 				manifest.PrintValidate()
 			} else {
+				var format output.Format
+				format, err = output.ParseFormat(outputFormat)
+				if err != nil {
+					return sanitizederror.NewWithError("invalid output format", err)
+				}
 				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+				_, err = testCommandExecute(output.Summary(format, outputFile), dirPath, fileName, gitBranch, testCase, failOnly, removeColor, format, outputFile)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -204,6 +212,8 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&outputFormat, "output-format", "", string(output.Text), "Format of the test results, one of text, json (engine responses), sarif or junit; other formats than text are written to stdout or to the output file and the summary is printed to stderr")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "", "", "File receiving the test results when an output format other than text is used")
 	return cmd
 }
 
@@ -230,7 +240,13 @@ type testFilter struct {
 
 var ftable = []Table{}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+// testResponses holds the outcome of each test case for the formatted output
+var testResponses []output.Response
+
+// testEngineResponses holds the engine responses of the tested policies for the json output
+var testEngineResponses []*response.EngineResponse
+
+func testCommandExecute(out io.Writer, dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool, format output.Format, outputFile string) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
@@ -243,12 +259,19 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		return rc, sanitizederror.NewWithError("a directory is required", err)
 	}
 
+	var writer io.WriteCloser
+	if format != output.Text {
+		if writer, err = output.Open(outputFile); err != nil {
+			return rc, sanitizederror.NewWithError("failed to open output file", err)
+		}
+	}
+
 	if len(testCase) != 0 {
 		parameters := map[string]string{"policy": "", "rule": "", "resource": ""}
 
 		for _, t := range strings.Split(testCase, ",") {
 			if !strings.Contains(t, "=") {
-				fmt.Printf("\n Invalid test-case-selector argument. Selecting all test cases. \n")
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument. Selecting all test cases. \n")
 				tf.enabled = false
 				break
 			}
@@ -258,7 +281,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 			_, ok := parameters[key]
 			if !ok {
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				tf.enabled = false
 				break
 			}
@@ -286,7 +309,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 
@@ -318,7 +341,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		_, cloneErr := gitutils.Clone(repoURL, fs, gitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -350,48 +373,58 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(out, fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
 		}
 
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(out, fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
 
 		if testFiles == 0 {
-			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+			fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
 		}
 	}
 
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("test errors: \n")
+		fmt.Fprintf(out, "test errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
+		}
+	}
+
+	if writer != nil {
+		err := output.Write(writer, format, version.BuildVersion, testResponses, testEngineResponses)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return rc, sanitizederror.NewWithError("failed to write test results", err)
 		}
 	}
 
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 
 	if rc.Fail > 0 && !failOnly {
-		printFailedTestResult(removeColor)
+		printFailedTestResult(out, removeColor)
 		os.Exit(1)
 	}
 	os.Exit(0)
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+func getLocalDirTestFiles(out io.Writer, fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -400,7 +433,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(out, fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
 			continue
 		}
 		if file.Name() == fileName {
@@ -416,7 +449,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(out, fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -728,7 +761,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(out io.Writer, fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
 	engineResponses := make([]*response.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &api.Test{}
@@ -754,7 +787,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return nil
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
@@ -773,7 +806,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	if userInfoFile != "" {
 		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
 		store.SetSubjects(subjectInfo)
@@ -798,7 +831,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	policies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -823,13 +856,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -852,9 +885,10 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 
 	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
+	common.ParseCRDs(openApiManager, resources)
 
 	filteredResources := []*unstructured.Unstructured{}
 	for _, r := range resources {
@@ -879,7 +913,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		for _, unique := range noDuplicateResources {
 			if resource.GetKind() == unique.GetKind() && resource.GetName() == unique.GetName() && resource.GetNamespace() == unique.GetNamespace() {
 				duplicate = true
-				fmt.Println("skipping duplicate resource, resource :", resource)
+				fmt.Fprintln(out, "skipping duplicate resource, resource :", resource)
 				break
 			}
 		}
@@ -899,7 +933,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	}
 
 	if len(policies) > 0 && len(noDuplicateResources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range policies {
@@ -916,7 +950,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -940,6 +974,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
 				Subresources:              subresources,
+				MergeSchemas:              openApiManager.MergeSchemas(),
+				Out:                       out,
 			}
 			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -949,8 +985,19 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
+	var locations output.Locations
+	if isGit {
+		var paths []string
+		for _, path := range resourceFullPath {
+			paths = append(paths, filepath.Join(policyResourcePath, path))
+		}
+		locations = output.LoadLocations(fs, paths...)
+	} else {
+		locations = output.LoadLocations(nil, resourceFullPath...)
+	}
+	testEngineResponses = append(testEngineResponses, engineResponses...)
 	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
-	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
+	resultErr := printTestResult(out, resultsMap, testResults, rc, failOnly, removeColor, locations)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
@@ -958,13 +1005,14 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	return
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly, removeColor bool) error {
-	printer := newTablePrinter(removeColor)
+func printTestResult(out io.Writer, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly, removeColor bool, locations output.Locations) error {
+	printer := newTablePrinter(out, removeColor)
 	table := []Table{}
 
 	var countDeprecatedResource int
 	testCount := 1
 	for _, v := range testResults {
+		policyName := v.Policy
 		res := new(Table)
 		res.ID = testCount
 		if v.Resources == nil {
@@ -1006,6 +1054,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				} else {
 					log.Log.V(2).Info("result not found", "key", resultKey)
 					res.Result = colorize(removeColor, boldYellow, "Not found")
+					recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, resource, "fail", "result not found")
 					rc.Fail++
 					table = append(table, *res)
 					ftable = append(ftable, *res)
@@ -1018,6 +1067,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 
 				if testRes.Result == v.Result {
 					res.Result = colorize(removeColor, boldGreen, "Pass")
+					recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, resource, "pass", fmt.Sprintf("expected %s", v.Result))
 					if testRes.Result == policyreportv1alpha2.StatusSkip {
 						rc.Skip++
 					} else {
@@ -1026,6 +1076,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				} else {
 					log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 					res.Result = colorize(removeColor, boldRed, "Fail")
+					recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, resource, "fail", fmt.Sprintf("expected %s, got %s: %s", v.Result, testRes.Result, testRes.Message))
 					rc.Fail++
 					ftable = append(ftable, *res)
 				}
@@ -1069,6 +1120,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			} else {
 				log.Log.V(2).Info("result not found", "key", resultKey)
 				res.Result = colorize(removeColor, boldYellow, "Not found")
+				recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, v.Resource, "fail", "result not found")
 				rc.Fail++
 				table = append(table, *res)
 				ftable = append(ftable, *res)
@@ -1081,6 +1133,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 
 			if testRes.Result == v.Result {
 				res.Result = colorize(removeColor, boldGreen, "Pass")
+				recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, v.Resource, "pass", fmt.Sprintf("expected %s", v.Result))
 				if testRes.Result == policyreportv1alpha2.StatusSkip {
 					rc.Skip++
 				} else {
@@ -1089,6 +1142,7 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			} else {
 				log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 				res.Result = colorize(removeColor, boldRed, "Fail")
+				recordTestResponse(policyName, v.Rule, locations, v.Kind, v.Namespace, v.Resource, "fail", fmt.Sprintf("expected %s, got %s: %s", v.Result, testRes.Result, testRes.Message))
 				rc.Fail++
 				ftable = append(ftable, *res)
 			}
@@ -1102,17 +1156,35 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			}
 		}
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 	printer.Print(table)
 	return nil
 }
 
-func printFailedTestResult(removeColor bool) {
-	printer := newTablePrinter(removeColor)
+// recordTestResponse records the outcome of a test case for the formatted output
+func recordTestResponse(policy, rule string, locations output.Locations, kind, namespace, name, status, message string) {
+	testResponses = append(testResponses, output.Response{
+		Policy: policy,
+		Resource: output.Resource{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			Location:  locations.Lookup(kind, namespace, name),
+		},
+		Rules: []output.Rule{{
+			Name:    rule,
+			Status:  status,
+			Message: message,
+		}},
+	})
+}
+
+func printFailedTestResult(out io.Writer, removeColor bool) {
+	printer := newTablePrinter(out, removeColor)
 	for i, v := range ftable {
 		v.ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
+	fmt.Fprintf(out, "\n")
 	printer.Print(ftable)
 }
@@ -23,9 +23,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineContext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	ut "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
@@ -94,6 +96,16 @@ type ApplyPolicyConfig struct {
 	Client                    dclient.Interface
 	AuditWarn                 bool
 	Subresources              []Subresource
+	MergeSchemas              patch.SchemaRegistry
+	// Out receives the human readable output, defaults to stdout
+	Out io.Writer
+}
+
+func (c ApplyPolicyConfig) out() io.Writer {
+	if c.Out == nil {
+		return os.Stdout
+	}
+	return c.Out
 }
 
 // HasVariables - check for variables in the policy
@@ -485,7 +497,8 @@ OuterLoop:
 		WithNamespaceLabels(namespaceLabels).
 		WithAdmissionInfo(c.UserInfo).
 		WithClient(c.Client).
-		WithSubresourcesInPolicy(subresources)
+		WithSubresourcesInPolicy(subresources).
+		WithMergeSchemas(c.MergeSchemas)
 
 	mutateResponse := engine.Mutate(context.Background(), registryclient.NewOrDie(), policyContext)
 	if mutateResponse != nil {
@@ -512,7 +525,7 @@ OuterLoop:
 	var validateResponse *response.EngineResponse
 	if policyHasValidate {
 		validateResponse = engine.Validate(context.Background(), registryclient.NewOrDie(), policyContext)
-		info = ProcessValidateEngineResponse(c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.out(), c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	if validateResponse != nil && !validateResponse.IsEmpty() {
@@ -522,7 +535,7 @@ OuterLoop:
 	verifyImageResponse, _ := engine.VerifyAndPatchImages(context.Background(), registryclient.NewOrDie(), policyContext)
 	if verifyImageResponse != nil && !verifyImageResponse.IsEmpty() {
 		engineResponses = append(engineResponses, verifyImageResponse)
-		info = ProcessValidateEngineResponse(c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.out(), c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	var policyHasGenerate bool
@@ -543,7 +556,7 @@ OuterLoop:
 			}
 			engineResponses = append(engineResponses, generateResponse)
 		}
-		updateResultCounts(c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
+		updateResultCounts(c.out(), c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
 	}
 
 	return engineResponses, info, nil
@@ -695,7 +708,18 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 	return resources, err
 }
 
-func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
+// ParseCRDs loads the schemas of the CRD manifests found in resources, so that
+// custom resources are validated and strategically merged according to their schema
+func ParseCRDs(openApiManager openapi.Manager, resources []*unstructured.Unstructured) {
+	for _, resource := range resources {
+		if resource.GetKind() == "CustomResourceDefinition" && resource.GroupVersionKind().Group == "apiextensions.k8s.io" {
+			openApiManager.ParseCRD(*resource)
+		}
+	}
+	openApiManager.UpdateMergeSchemas()
+}
+
+func ProcessValidateEngineResponse(out io.Writer, policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
 	var violatedRules []kyvernov1.ViolatedRule
 
 	printCount := 0
@@ -738,14 +762,14 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 					if !policyReport {
 						if printCount < 1 {
 							if auditWarning {
-								fmt.Printf("\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
 							} else {
-								fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
 							}
 							printCount++
 						}
 
-						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
+						fmt.Fprintf(out, "%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
 				case response.RuleStatusError:
@@ -794,7 +818,7 @@ func buildPVInfo(er *response.EngineResponse, violatedRules []kyvernov1.Violated
 	return info
 }
 
-func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
+func updateResultCounts(out io.Writer, policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
@@ -806,10 +830,10 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *respon
 					rc.Pass++
 				} else {
 					if printCount < 1 {
-						fmt.Println("\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
+						fmt.Fprintln(out, "\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
+					fmt.Fprintf(out, "%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
 
 					if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
 						rc.Warn++
@@ -883,17 +907,17 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 					c.Rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status == response.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 					c.Rc.Skip++
 				} else if mutateResponseRule.Status == response.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
+					fmt.Fprintf(c.out(), "\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
 					c.Rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+						fmt.Fprintf(c.out(), "\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
+					fmt.Fprintf(c.out(), "%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
 					c.Rc.Fail++
 				}
 				continue
@@ -914,16 +938,16 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !c.Stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprintf(c.out(), "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(c.MutateLogPath, c.MutateLogPathIsDir, string(yamlEncodedResource), c.Resource.GetName()+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(c.out(), "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// WriteJUnit writes the responses as a JUnit XML report, with one test suite per policy
+// and one test case per rule and resource, warnings don't fail the test case
+func WriteJUnit(w io.Writer, responses []Response) error {
+	report := junitTestSuites{Name: "kyverno"}
+	suites := map[string]int{}
+	for _, response := range responses {
+		index, ok := suites[response.Policy]
+		if !ok {
+			index = len(report.Suites)
+			suites[response.Policy] = index
+			report.Suites = append(report.Suites, junitTestSuite{Name: response.Policy})
+		}
+		suite := &report.Suites[index]
+		for _, rule := range response.Rules {
+			testCase := junitTestCase{
+				Name:      rule.Name + " " + response.Resource.String(),
+				ClassName: response.Policy,
+			}
+			if l := response.Resource.Location; l != nil {
+				testCase.File = l.File
+			}
+			switch rule.Status {
+			case "fail":
+				testCase.Failure = &junitMessage{Message: rule.Message, Type: rule.Status}
+				suite.Failures++
+			case "error":
+				testCase.Error = &junitMessage{Message: rule.Message, Type: rule.Status}
+				suite.Errors++
+			case "skip":
+				testCase.Skipped = &junitMessage{Message: rule.Message}
+				suite.Skipped++
+			case "warn":
+				testCase.SystemOut = "warning: " + rule.Message
+			}
+			suite.Tests++
+			suite.Cases = append(suite.Cases, testCase)
+		}
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,126 @@
+package output
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"sigs.k8s.io/yaml"
+)
+
+// Location is the file and line a resource is declared at
+type Location struct {
+	File string `json:"file"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Locations indexes the locations of resources by kind, namespace and name
+type Locations map[string]Location
+
+func locationKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// LoadLocations indexes the resources declared in the given yaml files or directories,
+// files are read from fs if not nil and from the local disk otherwise, paths that
+// can't be read (stdin, urls, ...) are ignored
+func LoadLocations(fs billy.Filesystem, paths ...string) Locations {
+	locations := Locations{}
+	for _, path := range paths {
+		if path == "" || path == "-" || strings.Contains(path, "://") {
+			continue
+		}
+		if fs != nil {
+			file, err := fs.Open(path)
+			if err != nil {
+				continue
+			}
+			data, err := io.ReadAll(file)
+			_ = file.Close()
+			if err == nil {
+				locations.Add(path, data)
+			}
+			continue
+		}
+		files := []string{path}
+		if info, err := os.Stat(path); err != nil {
+			continue
+		} else if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				continue
+			}
+			files = nil
+			for _, entry := range entries {
+				if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+		for _, file := range files {
+			// resource files are provided by the user running the CLI
+			data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304
+			if err == nil {
+				locations.Add(file, data)
+			}
+		}
+	}
+	return locations
+}
+
+// Add indexes the resources declared in a yaml file, the line of a resource
+// is the first line of its document that is neither blank nor a comment
+func (l Locations) Add(file string, data []byte) {
+	start := -1
+	var document []string
+	flush := func() {
+		defer func() {
+			start, document = -1, nil
+		}()
+		if start < 0 {
+			return
+		}
+		var resource struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(strings.Join(document, "\n")), &resource); err != nil || resource.Kind == "" {
+			return
+		}
+		key := locationKey(resource.Kind, resource.Metadata.Namespace, resource.Metadata.Name)
+		if _, ok := l[key]; !ok {
+			l[key] = Location{File: filepath.ToSlash(file), Line: start + 1}
+		}
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "---") {
+			flush()
+			continue
+		}
+		if start < 0 {
+			if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			start = i
+		}
+		document = append(document, line)
+	}
+	flush()
+}
+
+// Lookup returns the location of a resource, resources declared without
+// namespace match any namespace as the namespace may have been defaulted
+func (l Locations) Lookup(kind, namespace, name string) *Location {
+	if location, ok := l[locationKey(kind, namespace, name)]; ok {
+		return &location
+	}
+	if location, ok := l[locationKey(kind, "", name)]; ok {
+		return &location
+	}
+	return nil
+}
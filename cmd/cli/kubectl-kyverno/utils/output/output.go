@@ -0,0 +1,122 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+)
+
+// Format is the format of the results printed by the apply and test commands
+type Format string
+
+const (
+	// Text is the default human readable output
+	Text Format = "text"
+	// JSON prints the engine responses as json
+	JSON Format = "json"
+	// SARIF prints the results as a SARIF 2.1.0 log, as expected by code scanning tools
+	SARIF Format = "sarif"
+	// JUnit prints the results as a JUnit XML report
+	JUnit Format = "junit"
+)
+
+// Formats is the list of supported output formats
+var Formats = []Format{Text, JSON, SARIF, JUnit}
+
+// ParseFormat parses an output format, an empty value is parsed as text
+func ParseFormat(value string) (Format, error) {
+	if value == "" {
+		return Text, nil
+	}
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(value) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format %s, supported formats are %v", value, Formats)
+}
+
+// Resource identifies the resource a policy was applied to
+type Resource struct {
+	APIVersion string    `json:"apiVersion,omitempty"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	Location   *Location `json:"location,omitempty"`
+}
+
+func (r Resource) String() string {
+	return r.Namespace + "/" + r.Kind + "/" + r.Name
+}
+
+// Rule is the result of a policy rule on a resource
+type Rule struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+	// Status is one of pass, fail, warn, error and skip
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Response is the result of applying a policy to a resource
+type Response struct {
+	Policy   string   `json:"policy"`
+	Resource Resource `json:"resource"`
+	Rules    []Rule   `json:"rules"`
+}
+
+// Open returns the writer receiving the formatted output, either the given file or stdout
+func Open(file string) (io.WriteCloser, error) {
+	if file != "" {
+		// the output file is provided by the user running the CLI
+		return os.Create(filepath.Clean(file)) // #nosec G304
+	}
+	return nopCloser{os.Stdout}, nil
+}
+
+// Summary returns the writer receiving the human readable output, stderr when the formatted output
+// is written to stdout so that both don't mix, stdout otherwise
+func Summary(format Format, file string) io.Writer {
+	if format != Text && file == "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// Write writes the results in the given format, json is written from the engine responses
+// and the other formats from the responses
+func Write(w io.Writer, format Format, toolVersion string, responses []Response, engineResponses []*response.EngineResponse) error {
+	switch format {
+	case JSON:
+		return WriteJSON(w, engineResponses)
+	case SARIF:
+		return WriteSARIF(w, toolVersion, responses)
+	case JUnit:
+		return WriteJUnit(w, responses)
+	default:
+		return fmt.Errorf("output format %s can't be written", format)
+	}
+}
+
+// WriteJSON writes the engine responses as an indented json array
+func WriteJSON(w io.Writer, engineResponses []*response.EngineResponse) error {
+	if engineResponses == nil {
+		engineResponses = []*response.EngineResponse{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(engineResponses)
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+)
+
+var resources = []byte(`# pods
+apiVersion: v1
+kind: Pod
+metadata:
+  name: first
+---
+
+# second pod
+apiVersion: v1
+kind: Pod
+metadata:
+  name: second
+  namespace: test
+`)
+
+func Test_ParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NilError(t, err)
+	assert.Equal(t, format, Text)
+	format, err = ParseFormat("SARIF")
+	assert.NilError(t, err)
+	assert.Equal(t, format, SARIF)
+	_, err = ParseFormat("xml")
+	assert.ErrorContains(t, err, "invalid output format")
+}
+
+func Test_Locations(t *testing.T) {
+	locations := Locations{}
+	locations.Add("resources.yaml", resources)
+	location := locations.Lookup("Pod", "default", "first")
+	assert.Assert(t, location != nil)
+	assert.Equal(t, *location, Location{File: "resources.yaml", Line: 2})
+	location = locations.Lookup("Pod", "test", "second")
+	assert.Assert(t, location != nil)
+	assert.Equal(t, location.Line, 9)
+	assert.Assert(t, locations.Lookup("Pod", "other", "second") == nil)
+}
+
+func newResponses() []Response {
+	return []Response{{
+		Policy: "pol",
+		Resource: Resource{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "first",
+			Location:  &Location{File: "resources.yaml", Line: 2},
+		},
+		Rules: []Rule{
+			{Name: "a", Status: "pass"},
+			{Name: "b", Status: "fail", Message: "failed"},
+			{Name: "c", Status: "warn", Message: "warned"},
+			{Name: "d", Status: "skip"},
+		},
+	}}
+}
+
+func Test_WriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, WriteSARIF(&buf, "v1", newResponses()))
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, log.Version, "2.1.0")
+	assert.Equal(t, len(log.Runs), 1)
+	run := log.Runs[0]
+	assert.Equal(t, len(run.Tool.Driver.Rules), 4)
+	assert.Equal(t, len(run.Results), 4)
+	failed := run.Results[1]
+	assert.Equal(t, failed.RuleID, "pol/b")
+	assert.Equal(t, failed.Kind, "fail")
+	assert.Equal(t, failed.Level, "error")
+	assert.Equal(t, failed.Message.Text, "failed")
+	assert.Equal(t, failed.Locations[0].PhysicalLocation.ArtifactLocation.URI, "resources.yaml")
+	assert.Equal(t, failed.Locations[0].PhysicalLocation.Region.StartLine, 2)
+	assert.Equal(t, run.Results[2].Level, "warning")
+	assert.Equal(t, run.Results[3].Kind, "notApplicable")
+}
+
+func Test_WriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, WriteJUnit(&buf, newResponses()))
+	var report junitTestSuites
+	assert.NilError(t, xml.Unmarshal(buf.Bytes(), &report))
+	assert.Equal(t, report.Tests, 4)
+	assert.Equal(t, report.Failures, 1)
+	assert.Equal(t, report.Skipped, 1)
+	assert.Equal(t, len(report.Suites), 1)
+	cases := report.Suites[0].Cases
+	assert.Equal(t, cases[1].Name, "b default/Pod/first")
+	assert.Equal(t, cases[1].Failure.Message, "failed")
+	assert.Equal(t, cases[2].SystemOut, "warning: warned")
+}
+
+func Test_WriteJSON(t *testing.T) {
+	er := &response.EngineResponse{
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{Name: "pol"},
+			Rules:  []response.RuleResponse{{Name: "a", Type: response.Validation, Status: response.RuleStatusFail, Message: "failed"}},
+		},
+	}
+	er.PatchedResource.SetAPIVersion("v1")
+	er.PatchedResource.SetKind("Pod")
+	er.PatchedResource.SetName("first")
+	var buf bytes.Buffer
+	assert.NilError(t, WriteJSON(&buf, []*response.EngineResponse{er}))
+	var ers []map[string]interface{}
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &ers))
+	assert.Equal(t, len(ers), 1)
+	assert.DeepEqual(t, ers[0]["PatchedResource"], map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "metadata": map[string]interface{}{"name": "first"}})
+	policyResponse := ers[0]["PolicyResponse"].(map[string]interface{})
+	rule := policyResponse["rules"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, rule["status"], "fail")
+	assert.Equal(t, rule["message"], "failed")
+	buf.Reset()
+	assert.NilError(t, WriteJSON(&buf, nil))
+	assert.Equal(t, buf.String(), "[]\n")
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifKindAndLevel maps a rule status to the kind and level of a SARIF result
+func sarifKindAndLevel(status string) (string, string) {
+	switch status {
+	case "fail", "error":
+		return "fail", "error"
+	case "warn":
+		return "fail", "warning"
+	case "skip":
+		return "notApplicable", "none"
+	default:
+		return "pass", "none"
+	}
+}
+
+// WriteSARIF writes the responses as a SARIF log, with one SARIF rule per policy rule
+// and results pointing at the resource file and line when known
+func WriteSARIF(w io.Writer, toolVersion string, responses []Response) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "kyverno",
+			InformationURI: "https://kyverno.io",
+			Version:        toolVersion,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndexes := map[string]int{}
+	for _, response := range responses {
+		for _, rule := range response.Rules {
+			id := response.Policy + "/" + rule.Name
+			index, ok := ruleIndexes[id]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndexes[id] = index
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               id,
+					Name:             rule.Name,
+					ShortDescription: sarifMessage{Text: "rule " + rule.Name + " of policy " + response.Policy},
+				})
+			}
+			kind, level := sarifKindAndLevel(rule.Status)
+			message := rule.Message
+			if message == "" {
+				message = rule.Status
+			}
+			location := sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               response.Resource.Name,
+					FullyQualifiedName: response.Resource.String(),
+					Kind:               "resource",
+				}},
+			}
+			if l := response.Resource.Location; l != nil {
+				location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: l.File}}
+				if l.Line > 0 {
+					location.PhysicalLocation.Region = &sarifRegion{StartLine: l.Line}
+				}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    id,
+				RuleIndex: index,
+				Kind:      kind,
+				Level:     level,
+				Message:   sarifMessage{Text: message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
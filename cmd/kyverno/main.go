@@ -31,10 +31,14 @@ import (
 	admissionreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/admission"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	blockedreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/blocked"
+	exportreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/export"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	scanrequestcontroller "github.com/kyverno/kyverno/pkg/controllers/report/scanrequest"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -145,6 +149,7 @@ func createNonLeaderControllers(
 		eventGenerator,
 		configuration,
 		informerCacheResolvers,
+		manager.MergeSchemas(),
 	)
 	return []internal.Controller{
 			internal.NewController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
@@ -161,7 +166,14 @@ func createReportControllers(
 	backgroundScan bool,
 	admissionReports bool,
 	reportsChunkSize int,
+	reportsPerResource bool,
+	reportsPerPolicy bool,
+	reportsAggregateByOwner bool,
+	reportsHistorySize int,
+	reportsExportEndpoint string,
+	reportsExportBatchSize int,
 	backgroundScanWorkers int,
+	kubeClient kubernetes.Interface,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	rclient registryclient.Client,
@@ -169,6 +181,7 @@ func createReportControllers(
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	configMapResolver resolvers.ConfigmapResolver,
+	metricsConfig metrics.MetricsConfigManager,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -187,6 +200,19 @@ func createReportControllers(
 			resourceReportController,
 			resourcereportcontroller.Workers,
 		))
+		var exporter exportreportcontroller.Exporter
+		if reportsExportEndpoint != "" {
+			exporter = exportreportcontroller.NewController(
+				reportsExportEndpoint,
+				reportsExportBatchSize,
+				exportreportcontroller.NewConfigMapStore(kubeClient.CoreV1().ConfigMaps(config.KyvernoNamespace())),
+			)
+			ctrls = append(ctrls, internal.NewController(
+				exportreportcontroller.ControllerName,
+				exporter,
+				exportreportcontroller.Workers,
+			))
+		}
 		ctrls = append(ctrls, internal.NewController(
 			aggregatereportcontroller.ControllerName,
 			aggregatereportcontroller.NewController(
@@ -195,7 +221,14 @@ func createReportControllers(
 				kyvernoV1.Policies(),
 				kyvernoV1.ClusterPolicies(),
 				resourceReportController,
+				exporter,
+				kubeClient.CoreV1(),
+				metricsConfig,
+				reportsHistorySize,
 				reportsChunkSize,
+				reportsPerResource,
+				reportsPerPolicy,
+				reportsAggregateByOwner,
 			),
 			aggregatereportcontroller.Workers,
 		))
@@ -223,9 +256,20 @@ func createReportControllers(
 					kubeInformer.Core().V1().Namespaces(),
 					resourceReportController,
 					configMapResolver,
+					metricsConfig,
 				),
 				backgroundScanWorkers,
 			))
+			ctrls = append(ctrls, internal.NewController(
+				scanrequestcontroller.ControllerName,
+				scanrequestcontroller.NewController(
+					kubeClient,
+					kyvernoClient,
+					metadataFactory,
+					kubeInformer.Core().V1().Namespaces(),
+				),
+				scanrequestcontroller.Workers,
+			))
 		}
 	}
 	return ctrls, func(ctx context.Context) error {
@@ -242,6 +286,12 @@ func createrLeaderControllers(
 	backgroundScan bool,
 	admissionReports bool,
 	reportsChunkSize int,
+	reportsPerResource bool,
+	reportsPerPolicy bool,
+	reportsAggregateByOwner bool,
+	reportsHistorySize int,
+	reportsExportEndpoint string,
+	reportsExportBatchSize int,
 	backgroundScanWorkers int,
 	serverIP string,
 	webhookTimeout int,
@@ -260,6 +310,7 @@ func createrLeaderControllers(
 	certRenewer tls.CertRenewer,
 	runtime runtimeutils.Runtime,
 	configMapResolver resolvers.ConfigmapResolver,
+	mergeSchemas patch.SchemaRegistry,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
@@ -272,6 +323,7 @@ func createrLeaderControllers(
 		eventGenerator,
 		kubeInformer.Core().V1().Namespaces(),
 		configMapResolver,
+		mergeSchemas,
 		logging.WithName("PolicyController"),
 		time.Hour,
 		metricsConfig,
@@ -328,7 +380,14 @@ func createrLeaderControllers(
 		backgroundScan,
 		admissionReports,
 		reportsChunkSize,
+		reportsPerResource,
+		reportsPerPolicy,
+		reportsAggregateByOwner,
+		reportsHistorySize,
+		reportsExportEndpoint,
+		reportsExportBatchSize,
 		backgroundScanWorkers,
+		kubeClient,
 		dynamicClient,
 		kyvernoClient,
 		rclient,
@@ -336,6 +395,7 @@ func createrLeaderControllers(
 		kubeInformer,
 		kyvernoInformer,
 		configMapResolver,
+		metricsConfig,
 	)
 	return append(
 			[]internal.Controller{
@@ -366,6 +426,15 @@ func main() {
 		backgroundScan             bool
 		admissionReports           bool
 		reportsChunkSize           int
+		blockedRequestsReports     bool
+		blockedRequestsRetention   time.Duration
+		blockedRequestsMaxResults  int
+		reportsPerResource         bool
+		reportsPerPolicy           bool
+		reportsAggregateByOwner    bool
+		reportsHistorySize         int
+		reportsExportEndpoint      string
+		reportsExportBatchSize     int
 		backgroundScanWorkers      int
 		dumpPayload                bool
 		leaderElectionRetryPeriod  time.Duration
@@ -385,7 +454,16 @@ func main() {
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable backgound scan.")
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
-	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results or blocked requests in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.BoolVar(&reportsPerResource, "reportsPerResource", false, "Create one policy report per resource, owned by the resource, instead of reports aggregated per namespace. Can't be used with reportsPerPolicy, reportsAggregateByOwner or reportsExportEndpoint.")
+	flagset.BoolVar(&reportsPerPolicy, "reportsPerPolicy", false, "Partition policy reports by namespace and policy, only the partitions of changed source reports are reconciled. Can't be used with reportsPerResource.")
+	flagset.BoolVar(&reportsAggregateByOwner, "reportsAggregateByOwner", false, "Aggregate the results of resources having the same top level owner (Deployment, StatefulSet, CronJob, ...) into a single result per owner in namespace reports. Can't be used with reportsPerResource.")
+	flagset.IntVar(&reportsHistorySize, "reportsHistorySize", 0, "Max number of report result transitions kept per namespace in history config maps of the Kyverno namespace, history is disabled if 0.")
+	flagset.StringVar(&reportsExportEndpoint, "reportsExportEndpoint", "", "HTTP endpoint receiving new, changed and resolved report results as CloudEvents, export is disabled if empty. Can't be used with reportsPerResource.")
+	flagset.IntVar(&reportsExportBatchSize, "reportsExportBatchSize", exportreportcontroller.DefaultBatchSize, "Max number of results sent to the reports export endpoint in a single request.")
+	flagset.BoolVar(&blockedRequestsReports, "blockedRequestsReports", false, "Enable or disable BlockedRequestReports recording admission requests blocked by enforced policies.")
+	flagset.DurationVar(&blockedRequestsRetention, "blockedRequestsRetention", blockedreportcontroller.DefaultRetention, "Duration blocked admission requests are kept in reports.")
+	flagset.IntVar(&blockedRequestsMaxResults, "blockedRequestsMaxResults", blockedreportcontroller.DefaultMaxResults, "Max number of blocked admission requests kept per namespace, older ones are dropped first.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	// config
@@ -408,6 +486,18 @@ func main() {
 	defer sdown()
 	// show version
 	showWarnings(logger)
+	if reportsPerResource && reportsExportEndpoint != "" {
+		logger.Error(errors.New("reports export is not supported with per resource reports"), "invalid flags")
+		os.Exit(1)
+	}
+	if reportsPerResource && reportsPerPolicy {
+		logger.Error(errors.New("per policy reports are not supported with per resource reports"), "invalid flags")
+		os.Exit(1)
+	}
+	if reportsPerResource && reportsAggregateByOwner {
+		logger.Error(errors.New("aggregation by owner is not supported with per resource reports"), "invalid flags")
+		os.Exit(1)
+	}
 	// create instrumented clients
 	kubeClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	leaderElectionClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
@@ -513,6 +603,21 @@ func main() {
 		openApiManager,
 		configMapResolver,
 	)
+	// blocked requests are recorded by every replica serving admission requests
+	var blockedRequests blockedreportcontroller.Recorder
+	if blockedRequestsReports {
+		blockedRequests = blockedreportcontroller.NewController(
+			kyvernoClient,
+			blockedRequestsRetention,
+			blockedRequestsMaxResults,
+			reportsChunkSize,
+		)
+		nonLeaderControllers = append(nonLeaderControllers, internal.NewController(
+			blockedreportcontroller.ControllerName,
+			blockedRequests,
+			blockedreportcontroller.Workers,
+		))
+	}
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, kyvernoInformer, kubeInformer, kubeKyvernoInformer, cacheInformer) {
 		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -552,6 +657,12 @@ func main() {
 				backgroundScan,
 				admissionReports,
 				reportsChunkSize,
+				reportsPerResource,
+				reportsPerPolicy,
+				reportsAggregateByOwner,
+				reportsHistorySize,
+				reportsExportEndpoint,
+				reportsExportBatchSize,
 				backgroundScanWorkers,
 				serverIP,
 				webhookTimeout,
@@ -570,6 +681,7 @@ func main() {
 				certRenewer,
 				runtime,
 				configMapResolver,
+				openApiManager.MergeSchemas(),
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -642,6 +754,8 @@ func main() {
 		urgen,
 		eventGenerator,
 		openApiManager,
+		openApiManager.MergeSchemas(),
+		blockedRequests,
 		admissionReports,
 	)
 	exceptionHandlers := webhooksexception.NewHandlers()
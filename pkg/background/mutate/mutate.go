@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -38,6 +39,7 @@ type MutateExistingController struct {
 
 	configuration          config.Configuration
 	informerCacheResolvers resolvers.ConfigmapResolver
+	mergeSchemas           patch.SchemaRegistry
 	eventGen               event.Interface
 
 	log logr.Logger
@@ -52,6 +54,7 @@ func NewMutateExistingController(
 	npolicyLister kyvernov1listers.PolicyLister,
 	dynamicConfig config.Configuration,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	mergeSchemas patch.SchemaRegistry,
 	eventGen event.Interface,
 	log logr.Logger,
 ) *MutateExistingController {
@@ -63,6 +66,7 @@ func NewMutateExistingController(
 		npolicyLister:          npolicyLister,
 		configuration:          dynamicConfig,
 		informerCacheResolvers: informerCacheResolvers,
+		mergeSchemas:           mergeSchemas,
 		eventGen:               eventGen,
 		log:                    log,
 	}
@@ -98,7 +102,7 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 			continue
 		}
 
-		er := engine.Mutate(context.TODO(), c.rclient, policyContext)
+		er := engine.Mutate(context.TODO(), c.rclient, policyContext.WithMergeSchemas(c.mergeSchemas))
 		for _, r := range er.PolicyResponse.Rules {
 			patched := r.PatchedTarget
 			patchedTargetSubresourceName := r.PatchedTargetSubresourceName
@@ -252,6 +256,13 @@ func addAnnotation(policy kyvernov1.PolicyInterface, patched *unstructured.Unstr
 		ann = make(map[string]string)
 	}
 	ann[utils.PolicyAnnotation] = string(result)
+	if policy.GetSpec().IsAnnotateMutations() {
+		mutations, err := utils.AppliedMutationsFromRules(policy, []response.RuleResponse{r})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON patch bytes: %v", err)
+		}
+		ann[utils.AppliedMutationsAnnotation] = utils.MergeAppliedMutationsAnnotation(ann, mutations)
+	}
 	patchedNew.SetAnnotations(ann)
 
 	return
@@ -0,0 +1,84 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Matches checks the resource against the match, exclude and conditions of the cleanup policy,
+// it returns true if the resource should be deleted by the policy.
+// The client and config map resolver are used to load the policy context entries, they can be nil
+// when the policy doesn't declare apiCall or configMap entries.
+func Matches(ctx context.Context, logger logr.Logger, client dclient.Interface, cmResolver resolvers.ConfigmapResolver, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, nsLabels map[string]string) (bool, error) {
+	spec := policy.GetSpec()
+	// match namespaces
+	if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
+		logger.V(5).Info("resource namespace didn't match policy namespace", "result", err)
+		return false, nil
+	}
+	// match resource with match/exclude clause
+	if matched := match.CheckMatchesResources(resource, spec.MatchResources, nsLabels); matched != nil {
+		logger.V(5).Info("resource/match didn't match", "result", matched)
+		return false, nil
+	}
+	if spec.ExcludeResources != nil {
+		if excluded := match.CheckMatchesResources(resource, *spec.ExcludeResources, nsLabels); excluded == nil {
+			logger.V(5).Info("resource/exclude matched")
+			return false, nil
+		} else {
+			logger.V(5).Info("resource/exclude didn't match", "result", excluded)
+		}
+	}
+	// check conditions
+	if spec.Conditions != nil {
+		enginectx := enginecontext.NewContext()
+		if err := enginectx.AddResource(resource.Object); err != nil {
+			return false, err
+		}
+		if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+			return false, err
+		}
+		if err := enginectx.AddImageInfos(&resource); err != nil {
+			return false, err
+		}
+		if err := loadContext(ctx, logger, client, cmResolver, spec.Context, enginectx); err != nil {
+			return false, err
+		}
+		passed, err := variables.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
+		if err != nil {
+			return false, err
+		}
+		if !passed {
+			logger.V(5).Info("conditions did not pass")
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func loadContext(ctx context.Context, logger logr.Logger, client dclient.Interface, cmResolver resolvers.ConfigmapResolver, entries []kyvernov1.ContextEntry, enginectx enginecontext.Interface) error {
+	for _, entry := range entries {
+		if entry.ImageRegistry != nil {
+			return fmt.Errorf("context entry %s: imageRegistry entries are not supported in cleanup policies", entry.Name)
+		}
+		if entry.APICall != nil && client == nil {
+			return fmt.Errorf("context entry %s requires a client to perform api calls", entry.Name)
+		}
+		if entry.ConfigMap != nil && cmResolver == nil {
+			return fmt.Errorf("context entry %s requires a config map resolver", entry.Name)
+		}
+	}
+	policyContext := engine.NewPolicyContextWithJsonContext(enginectx).WithClient(client).WithInformerCacheResolver(cmResolver)
+	return engine.LoadContext(ctx, logger, nil, entries, policyContext, "")
+}
@@ -0,0 +1,94 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_Matches(t *testing.T) {
+	resolver, err := resolvers.NewClientBasedResolver(kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "teams", Namespace: "kyverno"},
+		Data:       map[string]string{"active": "dev,ops"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": "legacy",
+			"labels": map[string]interface{}{
+				"team": "legacy",
+			},
+		},
+	}}
+	conditions := &kyvernov2beta1.AnyAllConditions{
+		AllConditions: []kyvernov2beta1.Condition{{
+			RawKey:   &v1.JSON{Raw: []byte(`"{{ team }}"`)},
+			Operator: kyvernov2beta1.ConditionOperators["AnyNotIn"],
+			RawValue: &v1.JSON{Raw: []byte(`"{{ split(teams.data.active, ',') }}"`)},
+		}},
+	}
+	teamVariable := kyvernov1.ContextEntry{
+		Name:     "team",
+		Variable: &kyvernov1.Variable{JMESPath: "request.object.metadata.labels.team"},
+	}
+	teamsConfigMap := kyvernov1.ContextEntry{
+		Name:      "teams",
+		ConfigMap: &kyvernov1.ConfigMapReference{Name: "teams", Namespace: "kyverno"},
+	}
+	tests := []struct {
+		name       string
+		context    []kyvernov1.ContextEntry
+		cmResolver resolvers.ConfigmapResolver
+		want       bool
+		wantErr    bool
+	}{{
+		name:       "variable and config map",
+		context:    []kyvernov1.ContextEntry{teamVariable, teamsConfigMap},
+		cmResolver: resolver,
+		want:       true,
+	}, {
+		name:    "config map without resolver",
+		context: []kyvernov1.ContextEntry{teamVariable, teamsConfigMap},
+		wantErr: true,
+	}, {
+		name:    "missing context",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov2alpha1.ClusterCleanupPolicy{
+				Spec: kyvernov2alpha1.CleanupPolicySpec{
+					Context: tt.context,
+					MatchResources: kyvernov2beta1.MatchResources{
+						Any: kyvernov1.ResourceFilters{{
+							ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Namespace"}},
+						}},
+					},
+					Conditions: conditions,
+				},
+			}
+			got, err := Matches(context.TODO(), logging.GlobalLogger(), nil, tt.cmResolver, policy, resource, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Matches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
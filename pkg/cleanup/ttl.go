@@ -0,0 +1,46 @@
+package cleanup
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TTLLabel is the label used to delete a resource without writing a cleanup policy, its value is either:
+	// - a duration relative to the resource creation time (e.g. 24h or 90m)
+	// - an absolute expiry time, as a date (2006-01-02) or a UTC time (2006-01-02T150405Z)
+	TTLLabel = "cleanup.kyverno.io/ttl"
+)
+
+var ttlTimeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T150405Z",
+}
+
+// ParseTTL returns the expiry time of a resource created at the given time with the given TTL label value
+func ParseTTL(value string, created time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("invalid %s label value %s, duration must not be negative", TTLLabel, value)
+		}
+		return created.Add(duration), nil
+	}
+	for _, layout := range ttlTimeLayouts {
+		if expiry, err := time.Parse(layout, value); err == nil {
+			return expiry, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid %s label value %s, it must be a duration (e.g. 24h) or a date (e.g. 2006-01-02 or 2006-01-02T150405Z)", TTLLabel, value)
+}
+
+// GetExpiry returns the expiry time of a resource from its TTL label, the boolean is false if the resource has no TTL label
+func GetExpiry(obj metav1.Object) (time.Time, bool, error) {
+	value, ok := obj.GetLabels()[TTLLabel]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expiry, err := ParseTTL(value, obj.GetCreationTimestamp().Time)
+	return expiry, true, err
+}
@@ -0,0 +1,45 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTTL(t *testing.T) {
+	created := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{{
+		value: "24h",
+		want:  created.Add(24 * time.Hour),
+	}, {
+		value: "1h30m",
+		want:  created.Add(90 * time.Minute),
+	}, {
+		value: "2023-02-01",
+		want:  time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		value: "2023-02-01T153000Z",
+		want:  time.Date(2023, 2, 1, 15, 30, 0, 0, time.UTC),
+	}, {
+		value:   "-1h",
+		wantErr: true,
+	}, {
+		value:   "tomorrow",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTTL(tt.value, created)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTTL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"context"
+	"time"
+
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// BlockedRequestReportsGetter has a method to return a BlockedRequestReportInterface.
+// A group's client should implement this interface.
+type BlockedRequestReportsGetter interface {
+	BlockedRequestReports(namespace string) BlockedRequestReportInterface
+}
+
+// BlockedRequestReportInterface has methods to work with BlockedRequestReport resources.
+type BlockedRequestReportInterface interface {
+	Create(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.CreateOptions) (*v1alpha2.BlockedRequestReport, error)
+	Update(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.UpdateOptions) (*v1alpha2.BlockedRequestReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.BlockedRequestReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.BlockedRequestReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BlockedRequestReport, err error)
+	BlockedRequestReportExpansion
+}
+
+// blockedRequestReports implements BlockedRequestReportInterface
+type blockedRequestReports struct {
+	client rest.Interface
+	ns     string
+}
+
+// newBlockedRequestReports returns a BlockedRequestReports
+func newBlockedRequestReports(c *KyvernoV1alpha2Client, namespace string) *blockedRequestReports {
+	return &blockedRequestReports{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the blockedRequestReport, and returns the corresponding blockedRequestReport object, and an error if there is any.
+func (c *blockedRequestReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	result = &v1alpha2.BlockedRequestReport{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BlockedRequestReports that match those selectors.
+func (c *blockedRequestReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.BlockedRequestReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha2.BlockedRequestReportList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested blockedRequestReports.
+func (c *blockedRequestReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a blockedRequestReport and creates it.  Returns the server's representation of the blockedRequestReport, and an error, if there is any.
+func (c *blockedRequestReports) Create(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.CreateOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	result = &v1alpha2.BlockedRequestReport{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(blockedRequestReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a blockedRequestReport and updates it. Returns the server's representation of the blockedRequestReport, and an error, if there is any.
+func (c *blockedRequestReports) Update(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.UpdateOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	result = &v1alpha2.BlockedRequestReport{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		Name(blockedRequestReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(blockedRequestReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the blockedRequestReport and deletes it. Returns an error if one occurs.
+func (c *blockedRequestReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *blockedRequestReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched blockedRequestReport.
+func (c *blockedRequestReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BlockedRequestReport, err error) {
+	result = &v1alpha2.BlockedRequestReport{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("blockedrequestreports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
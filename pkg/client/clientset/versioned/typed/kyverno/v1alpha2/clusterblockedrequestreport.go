@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"context"
+	"time"
+
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterBlockedRequestReportsGetter has a method to return a ClusterBlockedRequestReportInterface.
+// A group's client should implement this interface.
+type ClusterBlockedRequestReportsGetter interface {
+	ClusterBlockedRequestReports() ClusterBlockedRequestReportInterface
+}
+
+// ClusterBlockedRequestReportInterface has methods to work with ClusterBlockedRequestReport resources.
+type ClusterBlockedRequestReportInterface interface {
+	Create(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.CreateOptions) (*v1alpha2.ClusterBlockedRequestReport, error)
+	Update(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.UpdateOptions) (*v1alpha2.ClusterBlockedRequestReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.ClusterBlockedRequestReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.ClusterBlockedRequestReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterBlockedRequestReport, err error)
+	ClusterBlockedRequestReportExpansion
+}
+
+// clusterBlockedRequestReports implements ClusterBlockedRequestReportInterface
+type clusterBlockedRequestReports struct {
+	client rest.Interface
+}
+
+// newClusterBlockedRequestReports returns a ClusterBlockedRequestReports
+func newClusterBlockedRequestReports(c *KyvernoV1alpha2Client) *clusterBlockedRequestReports {
+	return &clusterBlockedRequestReports{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterBlockedRequestReport, and returns the corresponding clusterBlockedRequestReport object, and an error if there is any.
+func (c *clusterBlockedRequestReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	result = &v1alpha2.ClusterBlockedRequestReport{}
+	err = c.client.Get().
+		Resource("clusterblockedrequestreports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterBlockedRequestReports that match those selectors.
+func (c *clusterBlockedRequestReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.ClusterBlockedRequestReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha2.ClusterBlockedRequestReportList{}
+	err = c.client.Get().
+		Resource("clusterblockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterBlockedRequestReports.
+func (c *clusterBlockedRequestReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterblockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterBlockedRequestReport and creates it.  Returns the server's representation of the clusterBlockedRequestReport, and an error, if there is any.
+func (c *clusterBlockedRequestReports) Create(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.CreateOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	result = &v1alpha2.ClusterBlockedRequestReport{}
+	err = c.client.Post().
+		Resource("clusterblockedrequestreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterBlockedRequestReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterBlockedRequestReport and updates it. Returns the server's representation of the clusterBlockedRequestReport, and an error, if there is any.
+func (c *clusterBlockedRequestReports) Update(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.UpdateOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	result = &v1alpha2.ClusterBlockedRequestReport{}
+	err = c.client.Put().
+		Resource("clusterblockedrequestreports").
+		Name(clusterBlockedRequestReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterBlockedRequestReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterBlockedRequestReport and deletes it. Returns an error if one occurs.
+func (c *clusterBlockedRequestReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterblockedrequestreports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterBlockedRequestReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterblockedrequestreports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterBlockedRequestReport.
+func (c *clusterBlockedRequestReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	result = &v1alpha2.ClusterBlockedRequestReport{}
+	err = c.client.Patch(pt).
+		Resource("clusterblockedrequestreports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeBlockedRequestReports implements BlockedRequestReportInterface
+type FakeBlockedRequestReports struct {
+	Fake *FakeKyvernoV1alpha2
+	ns   string
+}
+
+var blockedrequestreportsResource = schema.GroupVersionResource{Group: "kyverno.io", Version: "v1alpha2", Resource: "blockedrequestreports"}
+
+var blockedrequestreportsKind = schema.GroupVersionKind{Group: "kyverno.io", Version: "v1alpha2", Kind: "BlockedRequestReport"}
+
+// Get takes name of the blockedRequestReport, and returns the corresponding blockedRequestReport object, and an error if there is any.
+func (c *FakeBlockedRequestReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(blockedrequestreportsResource, c.ns, name), &v1alpha2.BlockedRequestReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BlockedRequestReport), err
+}
+
+// List takes label and field selectors, and returns the list of BlockedRequestReports that match those selectors.
+func (c *FakeBlockedRequestReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.BlockedRequestReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(blockedrequestreportsResource, blockedrequestreportsKind, c.ns, opts), &v1alpha2.BlockedRequestReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.BlockedRequestReportList{ListMeta: obj.(*v1alpha2.BlockedRequestReportList).ListMeta}
+	for _, item := range obj.(*v1alpha2.BlockedRequestReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested blockedRequestReports.
+func (c *FakeBlockedRequestReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(blockedrequestreportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a blockedRequestReport and creates it.  Returns the server's representation of the blockedRequestReport, and an error, if there is any.
+func (c *FakeBlockedRequestReports) Create(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.CreateOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(blockedrequestreportsResource, c.ns, blockedRequestReport), &v1alpha2.BlockedRequestReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BlockedRequestReport), err
+}
+
+// Update takes the representation of a blockedRequestReport and updates it. Returns the server's representation of the blockedRequestReport, and an error, if there is any.
+func (c *FakeBlockedRequestReports) Update(ctx context.Context, blockedRequestReport *v1alpha2.BlockedRequestReport, opts v1.UpdateOptions) (result *v1alpha2.BlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(blockedrequestreportsResource, c.ns, blockedRequestReport), &v1alpha2.BlockedRequestReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BlockedRequestReport), err
+}
+
+// Delete takes name of the blockedRequestReport and deletes it. Returns an error if one occurs.
+func (c *FakeBlockedRequestReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(blockedrequestreportsResource, c.ns, name, opts), &v1alpha2.BlockedRequestReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeBlockedRequestReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(blockedrequestreportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.BlockedRequestReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched blockedRequestReport.
+func (c *FakeBlockedRequestReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(blockedrequestreportsResource, c.ns, name, pt, data, subresources...), &v1alpha2.BlockedRequestReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BlockedRequestReport), err
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterBlockedRequestReports implements ClusterBlockedRequestReportInterface
+type FakeClusterBlockedRequestReports struct {
+	Fake *FakeKyvernoV1alpha2
+}
+
+var clusterblockedrequestreportsResource = schema.GroupVersionResource{Group: "kyverno.io", Version: "v1alpha2", Resource: "clusterblockedrequestreports"}
+
+var clusterblockedrequestreportsKind = schema.GroupVersionKind{Group: "kyverno.io", Version: "v1alpha2", Kind: "ClusterBlockedRequestReport"}
+
+// Get takes name of the clusterBlockedRequestReport, and returns the corresponding clusterBlockedRequestReport object, and an error if there is any.
+func (c *FakeClusterBlockedRequestReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterblockedrequestreportsResource, name), &v1alpha2.ClusterBlockedRequestReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterBlockedRequestReport), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterBlockedRequestReports that match those selectors.
+func (c *FakeClusterBlockedRequestReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.ClusterBlockedRequestReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterblockedrequestreportsResource, clusterblockedrequestreportsKind, opts), &v1alpha2.ClusterBlockedRequestReportList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.ClusterBlockedRequestReportList{ListMeta: obj.(*v1alpha2.ClusterBlockedRequestReportList).ListMeta}
+	for _, item := range obj.(*v1alpha2.ClusterBlockedRequestReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterBlockedRequestReports.
+func (c *FakeClusterBlockedRequestReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterblockedrequestreportsResource, opts))
+}
+
+// Create takes the representation of a clusterBlockedRequestReport and creates it.  Returns the server's representation of the clusterBlockedRequestReport, and an error, if there is any.
+func (c *FakeClusterBlockedRequestReports) Create(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.CreateOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterblockedrequestreportsResource, clusterBlockedRequestReport), &v1alpha2.ClusterBlockedRequestReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterBlockedRequestReport), err
+}
+
+// Update takes the representation of a clusterBlockedRequestReport and updates it. Returns the server's representation of the clusterBlockedRequestReport, and an error, if there is any.
+func (c *FakeClusterBlockedRequestReports) Update(ctx context.Context, clusterBlockedRequestReport *v1alpha2.ClusterBlockedRequestReport, opts v1.UpdateOptions) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterblockedrequestreportsResource, clusterBlockedRequestReport), &v1alpha2.ClusterBlockedRequestReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterBlockedRequestReport), err
+}
+
+// Delete takes name of the clusterBlockedRequestReport and deletes it. Returns an error if one occurs.
+func (c *FakeClusterBlockedRequestReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterblockedrequestreportsResource, name, opts), &v1alpha2.ClusterBlockedRequestReport{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterBlockedRequestReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterblockedrequestreportsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.ClusterBlockedRequestReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterBlockedRequestReport.
+func (c *FakeClusterBlockedRequestReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.ClusterBlockedRequestReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterblockedrequestreportsResource, name, pt, data, subresources...), &v1alpha2.ClusterBlockedRequestReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.ClusterBlockedRequestReport), err
+}
@@ -36,6 +36,10 @@ func (c *FakeKyvernoV1alpha2) BackgroundScanReports(namespace string) v1alpha2.B
 	return &FakeBackgroundScanReports{c, namespace}
 }
 
+func (c *FakeKyvernoV1alpha2) BlockedRequestReports(namespace string) v1alpha2.BlockedRequestReportInterface {
+	return &FakeBlockedRequestReports{c, namespace}
+}
+
 func (c *FakeKyvernoV1alpha2) ClusterAdmissionReports() v1alpha2.ClusterAdmissionReportInterface {
 	return &FakeClusterAdmissionReports{c}
 }
@@ -44,6 +48,10 @@ func (c *FakeKyvernoV1alpha2) ClusterBackgroundScanReports() v1alpha2.ClusterBac
 	return &FakeClusterBackgroundScanReports{c}
 }
 
+func (c *FakeKyvernoV1alpha2) ClusterBlockedRequestReports() v1alpha2.ClusterBlockedRequestReportInterface {
+	return &FakeClusterBlockedRequestReports{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKyvernoV1alpha2) RESTClient() rest.Interface {
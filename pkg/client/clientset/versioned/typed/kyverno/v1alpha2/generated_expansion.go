@@ -22,6 +22,10 @@ type AdmissionReportExpansion interface{}
 
 type BackgroundScanReportExpansion interface{}
 
+type BlockedRequestReportExpansion interface{}
+
 type ClusterAdmissionReportExpansion interface{}
 
 type ClusterBackgroundScanReportExpansion interface{}
+
+type ClusterBlockedRequestReportExpansion interface{}
@@ -30,8 +30,10 @@ type KyvernoV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	AdmissionReportsGetter
 	BackgroundScanReportsGetter
+	BlockedRequestReportsGetter
 	ClusterAdmissionReportsGetter
 	ClusterBackgroundScanReportsGetter
+	ClusterBlockedRequestReportsGetter
 }
 
 // KyvernoV1alpha2Client is used to interact with features provided by the kyverno.io group.
@@ -47,6 +49,10 @@ func (c *KyvernoV1alpha2Client) BackgroundScanReports(namespace string) Backgrou
 	return newBackgroundScanReports(c, namespace)
 }
 
+func (c *KyvernoV1alpha2Client) BlockedRequestReports(namespace string) BlockedRequestReportInterface {
+	return newBlockedRequestReports(c, namespace)
+}
+
 func (c *KyvernoV1alpha2Client) ClusterAdmissionReports() ClusterAdmissionReportInterface {
 	return newClusterAdmissionReports(c)
 }
@@ -55,6 +61,10 @@ func (c *KyvernoV1alpha2Client) ClusterBackgroundScanReports() ClusterBackground
 	return newClusterBackgroundScanReports(c)
 }
 
+func (c *KyvernoV1alpha2Client) ClusterBlockedRequestReports() ClusterBlockedRequestReportInterface {
+	return newClusterBlockedRequestReports(c)
+}
+
 // NewForConfig creates a new KyvernoV1alpha2Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
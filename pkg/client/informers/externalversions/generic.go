@@ -69,10 +69,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().AdmissionReports().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().BackgroundScanReports().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("blockedrequestreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().BlockedRequestReports().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().ClusterAdmissionReports().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().ClusterBackgroundScanReports().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("clusterblockedrequestreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V1alpha2().ClusterBlockedRequestReports().Informer()}, nil
 
 		// Group=kyverno.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("updaterequests"):
@@ -28,10 +28,14 @@ type Interface interface {
 	AdmissionReports() AdmissionReportInformer
 	// BackgroundScanReports returns a BackgroundScanReportInformer.
 	BackgroundScanReports() BackgroundScanReportInformer
+	// BlockedRequestReports returns a BlockedRequestReportInformer.
+	BlockedRequestReports() BlockedRequestReportInformer
 	// ClusterAdmissionReports returns a ClusterAdmissionReportInformer.
 	ClusterAdmissionReports() ClusterAdmissionReportInformer
 	// ClusterBackgroundScanReports returns a ClusterBackgroundScanReportInformer.
 	ClusterBackgroundScanReports() ClusterBackgroundScanReportInformer
+	// ClusterBlockedRequestReports returns a ClusterBlockedRequestReportInformer.
+	ClusterBlockedRequestReports() ClusterBlockedRequestReportInformer
 }
 
 type version struct {
@@ -55,6 +59,11 @@ func (v *version) BackgroundScanReports() BackgroundScanReportInformer {
 	return &backgroundScanReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BlockedRequestReports returns a BlockedRequestReportInformer.
+func (v *version) BlockedRequestReports() BlockedRequestReportInformer {
+	return &blockedRequestReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterAdmissionReports returns a ClusterAdmissionReportInformer.
 func (v *version) ClusterAdmissionReports() ClusterAdmissionReportInformer {
 	return &clusterAdmissionReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -64,3 +73,8 @@ func (v *version) ClusterAdmissionReports() ClusterAdmissionReportInformer {
 func (v *version) ClusterBackgroundScanReports() ClusterBackgroundScanReportInformer {
 	return &clusterBackgroundScanReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// ClusterBlockedRequestReports returns a ClusterBlockedRequestReportInformer.
+func (v *version) ClusterBlockedRequestReports() ClusterBlockedRequestReportInformer {
+	return &clusterBlockedRequestReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// BlockedRequestReportLister helps list BlockedRequestReports.
+// All objects returned here must be treated as read-only.
+type BlockedRequestReportLister interface {
+	// List lists all BlockedRequestReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.BlockedRequestReport, err error)
+	// BlockedRequestReports returns an object that can list and get BlockedRequestReports.
+	BlockedRequestReports(namespace string) BlockedRequestReportNamespaceLister
+	BlockedRequestReportListerExpansion
+}
+
+// blockedRequestReportLister implements the BlockedRequestReportLister interface.
+type blockedRequestReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewBlockedRequestReportLister returns a new BlockedRequestReportLister.
+func NewBlockedRequestReportLister(indexer cache.Indexer) BlockedRequestReportLister {
+	return &blockedRequestReportLister{indexer: indexer}
+}
+
+// List lists all BlockedRequestReports in the indexer.
+func (s *blockedRequestReportLister) List(selector labels.Selector) (ret []*v1alpha2.BlockedRequestReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.BlockedRequestReport))
+	})
+	return ret, err
+}
+
+// BlockedRequestReports returns an object that can list and get BlockedRequestReports.
+func (s *blockedRequestReportLister) BlockedRequestReports(namespace string) BlockedRequestReportNamespaceLister {
+	return blockedRequestReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// BlockedRequestReportNamespaceLister helps list and get BlockedRequestReports.
+// All objects returned here must be treated as read-only.
+type BlockedRequestReportNamespaceLister interface {
+	// List lists all BlockedRequestReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.BlockedRequestReport, err error)
+	// Get retrieves the BlockedRequestReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.BlockedRequestReport, error)
+	BlockedRequestReportNamespaceListerExpansion
+}
+
+// blockedRequestReportNamespaceLister implements the BlockedRequestReportNamespaceLister
+// interface.
+type blockedRequestReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all BlockedRequestReports in the indexer for a given namespace.
+func (s blockedRequestReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.BlockedRequestReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.BlockedRequestReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the BlockedRequestReport from the indexer for a given namespace and name.
+func (s blockedRequestReportNamespaceLister) Get(name string) (*v1alpha2.BlockedRequestReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("blockedrequestreport"), name)
+	}
+	return obj.(*v1alpha2.BlockedRequestReport), nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterBlockedRequestReportLister helps list ClusterBlockedRequestReports.
+// All objects returned here must be treated as read-only.
+type ClusterBlockedRequestReportLister interface {
+	// List lists all ClusterBlockedRequestReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.ClusterBlockedRequestReport, err error)
+	// Get retrieves the ClusterBlockedRequestReport from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.ClusterBlockedRequestReport, error)
+	ClusterBlockedRequestReportListerExpansion
+}
+
+// clusterBlockedRequestReportLister implements the ClusterBlockedRequestReportLister interface.
+type clusterBlockedRequestReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterBlockedRequestReportLister returns a new ClusterBlockedRequestReportLister.
+func NewClusterBlockedRequestReportLister(indexer cache.Indexer) ClusterBlockedRequestReportLister {
+	return &clusterBlockedRequestReportLister{indexer: indexer}
+}
+
+// List lists all ClusterBlockedRequestReports in the indexer.
+func (s *clusterBlockedRequestReportLister) List(selector labels.Selector) (ret []*v1alpha2.ClusterBlockedRequestReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.ClusterBlockedRequestReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterBlockedRequestReport from the index for a given name.
+func (s *clusterBlockedRequestReportLister) Get(name string) (*v1alpha2.ClusterBlockedRequestReport, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("clusterblockedrequestreport"), name)
+	}
+	return obj.(*v1alpha2.ClusterBlockedRequestReport), nil
+}
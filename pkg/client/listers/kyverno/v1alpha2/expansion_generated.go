@@ -34,6 +34,14 @@ type BackgroundScanReportListerExpansion interface{}
 // BackgroundScanReportNamespaceLister.
 type BackgroundScanReportNamespaceListerExpansion interface{}
 
+// BlockedRequestReportListerExpansion allows custom methods to be added to
+// BlockedRequestReportLister.
+type BlockedRequestReportListerExpansion interface{}
+
+// BlockedRequestReportNamespaceListerExpansion allows custom methods to be added to
+// BlockedRequestReportNamespaceLister.
+type BlockedRequestReportNamespaceListerExpansion interface{}
+
 // ClusterAdmissionReportListerExpansion allows custom methods to be added to
 // ClusterAdmissionReportLister.
 type ClusterAdmissionReportListerExpansion interface{}
@@ -41,3 +49,7 @@ type ClusterAdmissionReportListerExpansion interface{}
 // ClusterBackgroundScanReportListerExpansion allows custom methods to be added to
 // ClusterBackgroundScanReportLister.
 type ClusterBackgroundScanReportListerExpansion interface{}
+
+// ClusterBlockedRequestReportListerExpansion allows custom methods to be added to
+// ClusterBlockedRequestReportLister.
+type ClusterBlockedRequestReportListerExpansion interface{}
@@ -39,6 +39,8 @@ type Interface interface {
 	ListResource(ctx context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error)
 	// DeleteResource deletes the specified resource
 	DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool) error
+	// DeleteResourceWithOptions deletes the specified resource with the given delete options
+	DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error
 	// CreateResource creates object for the specified resource/namespace
 	CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error)
 	// UpdateResource updates object for the specified resource/namespace
@@ -158,6 +160,11 @@ func (c *client) DeleteResource(ctx context.Context, apiVersion string, kind str
 	if dryRun {
 		options = metav1.DeleteOptions{DryRun: []string{metav1.DryRunAll}}
 	}
+	return c.DeleteResourceWithOptions(ctx, apiVersion, kind, namespace, name, options)
+}
+
+// DeleteResourceWithOptions deletes the specified resource with the given delete options
+func (c *client) DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error {
 	return c.getResourceInterface(apiVersion, kind, namespace).Delete(ctx, name, options)
 }
 
@@ -5,8 +5,10 @@ import (
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1alpha2"
 	admissionreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/admissionreports"
 	backgroundscanreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/backgroundscanreports"
+	blockedrequestreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/blockedrequestreports"
 	clusteradmissionreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/clusteradmissionreports"
 	clusterbackgroundscanreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/clusterbackgroundscanreports"
+	clusterblockedrequestreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/clusterblockedrequestreports"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -40,6 +42,10 @@ func (c *withMetrics) BackgroundScanReports(namespace string) github_com_kyverno
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "BackgroundScanReport", c.clientType)
 	return backgroundscanreports.WithMetrics(c.inner.BackgroundScanReports(namespace), recorder)
 }
+func (c *withMetrics) BlockedRequestReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BlockedRequestReportInterface {
+	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "BlockedRequestReport", c.clientType)
+	return blockedrequestreports.WithMetrics(c.inner.BlockedRequestReports(namespace), recorder)
+}
 func (c *withMetrics) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterAdmissionReport", c.clientType)
 	return clusteradmissionreports.WithMetrics(c.inner.ClusterAdmissionReports(), recorder)
@@ -48,6 +54,10 @@ func (c *withMetrics) ClusterBackgroundScanReports() github_com_kyverno_kyverno_
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterBackgroundScanReport", c.clientType)
 	return clusterbackgroundscanreports.WithMetrics(c.inner.ClusterBackgroundScanReports(), recorder)
 }
+func (c *withMetrics) ClusterBlockedRequestReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBlockedRequestReportInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterBlockedRequestReport", c.clientType)
+	return clusterblockedrequestreports.WithMetrics(c.inner.ClusterBlockedRequestReports(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
@@ -63,12 +73,18 @@ func (c *withTracing) AdmissionReports(namespace string) github_com_kyverno_kyve
 func (c *withTracing) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
 	return backgroundscanreports.WithTracing(c.inner.BackgroundScanReports(namespace), c.client, "BackgroundScanReport")
 }
+func (c *withTracing) BlockedRequestReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BlockedRequestReportInterface {
+	return blockedrequestreports.WithTracing(c.inner.BlockedRequestReports(namespace), c.client, "BlockedRequestReport")
+}
 func (c *withTracing) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
 	return clusteradmissionreports.WithTracing(c.inner.ClusterAdmissionReports(), c.client, "ClusterAdmissionReport")
 }
 func (c *withTracing) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
 	return clusterbackgroundscanreports.WithTracing(c.inner.ClusterBackgroundScanReports(), c.client, "ClusterBackgroundScanReport")
 }
+func (c *withTracing) ClusterBlockedRequestReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBlockedRequestReportInterface {
+	return clusterblockedrequestreports.WithTracing(c.inner.ClusterBlockedRequestReports(), c.client, "ClusterBlockedRequestReport")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
@@ -84,9 +100,15 @@ func (c *withLogging) AdmissionReports(namespace string) github_com_kyverno_kyve
 func (c *withLogging) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
 	return backgroundscanreports.WithLogging(c.inner.BackgroundScanReports(namespace), c.logger.WithValues("resource", "BackgroundScanReports").WithValues("namespace", namespace))
 }
+func (c *withLogging) BlockedRequestReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BlockedRequestReportInterface {
+	return blockedrequestreports.WithLogging(c.inner.BlockedRequestReports(namespace), c.logger.WithValues("resource", "BlockedRequestReports").WithValues("namespace", namespace))
+}
 func (c *withLogging) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
 	return clusteradmissionreports.WithLogging(c.inner.ClusterAdmissionReports(), c.logger.WithValues("resource", "ClusterAdmissionReports"))
 }
 func (c *withLogging) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
 	return clusterbackgroundscanreports.WithLogging(c.inner.ClusterBackgroundScanReports(), c.logger.WithValues("resource", "ClusterBackgroundScanReports"))
 }
+func (c *withLogging) ClusterBlockedRequestReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBlockedRequestReportInterface {
+	return clusterblockedrequestreports.WithLogging(c.inner.ClusterBlockedRequestReports(), c.logger.WithValues("resource", "ClusterBlockedRequestReports"))
+}
@@ -0,0 +1,196 @@
+package cleanup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	batchv1informers "k8s.io/client-go/informers/batch/v1"
+	"k8s.io/client-go/kubernetes"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	SchedulerName    = "cleanup-scheduler"
+	SchedulerWorkers = 3
+	// schedulerInterval is how often policies are checked, cron schedules have a minute granularity
+	schedulerInterval = 10 * time.Second
+)
+
+// CleanupFunc executes the cleanup policy identified by its key
+type CleanupFunc = func(context.Context, logr.Logger, string, time.Time) error
+
+type scheduler struct {
+	// clients
+	client kubernetes.Interface
+
+	// listers
+	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
+	polLister  kyvernov2alpha1listers.CleanupPolicyLister
+	cjLister   batchv1listers.CronJobLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// cleanup
+	cleanup CleanupFunc
+
+	// lastRuns holds the last time policies were queued, the policy status
+	// is used when a policy didn't run yet in this process (after a restart or a leader change)
+	lock     sync.Mutex
+	lastRuns map[string]time.Time
+}
+
+// NewScheduler returns a controller running cleanup policies in process, according to their schedule.
+// It is an alternative to NewController, which creates a CronJob per policy, the CronJobs created
+// by NewController are deleted so that policies don't run twice after switching schedulers.
+func NewScheduler(
+	client kubernetes.Interface,
+	cpolInformer kyvernov2alpha1informers.ClusterCleanupPolicyInformer,
+	polInformer kyvernov2alpha1informers.CleanupPolicyInformer,
+	cjInformer batchv1informers.CronJobInformer,
+	cleanup CleanupFunc,
+) controllers.Controller {
+	return &scheduler{
+		client:     client,
+		cpolLister: cpolInformer.Lister(),
+		polLister:  polInformer.Lister(),
+		cjLister:   cjInformer.Lister(),
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), SchedulerName),
+		cleanup:    cleanup,
+		lastRuns:   map[string]time.Time{},
+	}
+}
+
+func (s *scheduler) Run(ctx context.Context, workers int) {
+	// failed executions are not retried, they are recorded in the policy status and the policy runs again at its next schedule
+	controllerutils.Run(ctx, logger.V(3), SchedulerName, time.Second, s.queue, workers, 0, s.reconcile, s.tick)
+}
+
+func (s *scheduler) tick(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.deleteCronJobs(ctx, logger)
+			s.enqueueDuePolicies(logger, now)
+		}
+	}
+}
+
+func (s *scheduler) enqueueDuePolicies(logger logr.Logger, now time.Time) {
+	policies, err := s.listPolicies()
+	if err != nil {
+		logger.Error(err, "failed to list cleanup policies")
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := sets.NewString()
+	for _, policy := range policies {
+		key, err := cache.MetaNamespaceKeyFunc(policy)
+		if err != nil {
+			logger.Error(err, "failed to compute policy key")
+			continue
+		}
+		keys.Insert(key)
+		next, err := policy.GetSpec().GetNextExecutionTime(lastRun(policy, s.lastRuns[key]))
+		if err != nil {
+			logger.Error(err, "failed to parse policy schedule", "policy", key)
+			continue
+		}
+		if !next.After(now) {
+			s.lastRuns[key] = now
+			s.queue.Add(key)
+		}
+	}
+	// forget deleted policies
+	for key := range s.lastRuns {
+		if !keys.Has(key) {
+			delete(s.lastRuns, key)
+		}
+	}
+}
+
+// deleteCronJobs deletes the CronJobs created for cleanup policies by the cronjob scheduler
+func (s *scheduler) deleteCronJobs(ctx context.Context, logger logr.Logger) {
+	selector := labels.SelectorFromSet(labels.Set{kyvernov1.LabelAppManagedBy: kyvernov1.ValueKyvernoApp})
+	cronJobs, err := s.cjLister.List(selector)
+	if err != nil {
+		logger.Error(err, "failed to list cronjobs")
+		return
+	}
+	for _, cronJob := range cronJobs {
+		if !isOwnedByCleanupPolicy(cronJob) {
+			continue
+		}
+		err := s.client.BatchV1().CronJobs(cronJob.Namespace).Delete(ctx, cronJob.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to delete cleanup policy cronjob", "namespace", cronJob.Namespace, "name", cronJob.Name)
+		} else {
+			logger.V(2).Info("deleted cleanup policy cronjob", "namespace", cronJob.Namespace, "name", cronJob.Name)
+		}
+	}
+}
+
+func isOwnedByCleanupPolicy(cronJob *batchv1.CronJob) bool {
+	for _, owner := range cronJob.OwnerReferences {
+		if owner.Kind == "ClusterCleanupPolicy" || owner.Kind == "CleanupPolicy" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scheduler) listPolicies() ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	var policies []kyvernov2alpha1.CleanupPolicyInterface
+	cpols, err := s.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := s.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	return policies, nil
+}
+
+func (s *scheduler) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	return s.cleanup(ctx, logger.WithValues("policy", key), key, time.Now())
+}
+
+// lastRun returns the most recent of the in process last run, the last execution recorded
+// in the policy status and the policy creation time
+func lastRun(policy kyvernov2alpha1.CleanupPolicyInterface, inProcess time.Time) time.Time {
+	last := policy.GetCreationTimestamp().Time
+	if status := policy.GetStatus(); status.LastExecution != nil && status.LastExecution.ExecutionTime.After(last) {
+		last = status.LastExecution.ExecutionTime.Time
+	}
+	if inProcess.After(last) {
+		last = inProcess
+	}
+	return last
+}
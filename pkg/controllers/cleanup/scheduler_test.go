@@ -0,0 +1,108 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/logging"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"gotest.tools/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_scheduler_enqueueDuePolicies(t *testing.T) {
+	created := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	policy := func(name, schedule string, lastExecution *time.Time) *kyvernov2alpha1.ClusterCleanupPolicy {
+		pol := &kyvernov2alpha1.ClusterCleanupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: kyvernov2alpha1.CleanupPolicySpec{
+				Schedule: schedule,
+			},
+		}
+		if lastExecution != nil {
+			pol.Status.LastExecution = &kyvernov2alpha1.ExecutionStatus{ExecutionTime: metav1.NewTime(*lastExecution)}
+		}
+		return pol
+	}
+	recent := created.Add(85 * time.Minute)
+	cpolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, cpolIndexer.Add(policy("hourly", "0 * * * *", nil)))
+	assert.NilError(t, cpolIndexer.Add(policy("hourly-ran", "0 * * * *", &recent)))
+	assert.NilError(t, cpolIndexer.Add(policy("daily", "0 0 * * *", nil)))
+	s := &scheduler{
+		cpolLister: kyvernov2alpha1listers.NewClusterCleanupPolicyLister(cpolIndexer),
+		polLister:  kyvernov2alpha1listers.NewCleanupPolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		queue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		lastRuns:   map[string]time.Time{},
+	}
+	defer s.queue.ShutDown()
+	now := created.Add(90 * time.Minute)
+	s.enqueueDuePolicies(logging.GlobalLogger(), now)
+	assert.Equal(t, s.queue.Len(), 1)
+	key, _ := s.queue.Get()
+	assert.Equal(t, key, "hourly")
+	s.queue.Done(key)
+	// the policy already ran in process, it is not due again before the next schedule
+	s.enqueueDuePolicies(logging.GlobalLogger(), now.Add(time.Minute))
+	assert.Equal(t, s.queue.Len(), 0)
+	s.enqueueDuePolicies(logging.GlobalLogger(), now.Add(time.Hour))
+	assert.Equal(t, s.queue.Len(), 2)
+	// deleted policies are forgotten
+	assert.Equal(t, len(s.lastRuns), 2)
+	assert.NilError(t, cpolIndexer.Delete(policy("hourly", "0 * * * *", nil)))
+	s.enqueueDuePolicies(logging.GlobalLogger(), now.Add(2*time.Hour))
+	_, found := s.lastRuns["hourly"]
+	assert.Assert(t, !found)
+}
+
+func Test_scheduler_deleteCronJobs(t *testing.T) {
+	cronJob := func(name, ownerKind string, managed bool) *batchv1.CronJob {
+		cj := &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "kyverno",
+				OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: "pol"}},
+			},
+		}
+		if managed {
+			controllerutils.SetManagedByKyvernoLabel(cj)
+		}
+		return cj
+	}
+	cronJobs := []*batchv1.CronJob{
+		cronJob("cpol", "ClusterCleanupPolicy", true),
+		cronJob("pol", "CleanupPolicy", true),
+		cronJob("other", "Deployment", true),
+		cronJob("unmanaged", "ClusterCleanupPolicy", false),
+	}
+	client := kubefake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cj := range cronJobs {
+		_, err := client.BatchV1().CronJobs(cj.Namespace).Create(context.TODO(), cj, metav1.CreateOptions{})
+		assert.NilError(t, err)
+		assert.NilError(t, indexer.Add(cj))
+	}
+	s := &scheduler{
+		client:   client,
+		cjLister: batchv1listers.NewCronJobLister(indexer),
+	}
+	s.deleteCronJobs(context.TODO(), logging.GlobalLogger())
+	list, err := client.BatchV1().CronJobs("kyverno").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	var names []string
+	for _, cj := range list.Items {
+		names = append(names, cj.Name)
+	}
+	assert.DeepEqual(t, names, []string{"other", "unmanaged"})
+}
@@ -0,0 +1,80 @@
+package exception
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exception-expiry-controller"
+	maxRetries     = 10
+)
+
+// controller deletes policy exceptions when they expire
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client versioned.Interface,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	controllerutils.AddDefaultEventHandlers(logger, polexInformer.Informer(), queue)
+	return &controller{
+		client:      client,
+		polexLister: polexInformer.Lister(),
+		queue:       queue,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if polex.Spec.ExpiresAt == nil {
+		return nil
+	}
+	if wait := time.Until(polex.Spec.ExpiresAt.Time); wait > 0 {
+		logger.V(4).Info("policy exception not expired yet", "expiresAt", polex.Spec.ExpiresAt)
+		c.queue.AddAfter(key, wait)
+		return nil
+	}
+	logger.Info("policy exception expired, deleting it", "expiresAt", polex.Spec.ExpiresAt)
+	uid := polex.GetUID()
+	err = c.client.KyvernoV2alpha1().PolicyExceptions(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		// don't delete the exception if it was recreated in the meantime
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
@@ -89,6 +89,7 @@ func (c *controller) sync() {
 	for _, crd := range crds.Items {
 		c.manager.ParseCRD(crd)
 	}
+	c.manager.UpdateMergeSchemas()
 
 	if err := c.updateInClusterKindToAPIVersions(); err != nil {
 		logger.Error(err, "sync failed, unable to update in-cluster api versions")
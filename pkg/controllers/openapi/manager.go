@@ -10,6 +10,7 @@ type Manager interface {
 	UseOpenAPIDocument(*openapiv2.Document) error
 	DeleteCRDFromPreviousSync()
 	ParseCRD(unstructured.Unstructured)
+	UpdateMergeSchemas()
 	UpdateKindToAPIVersions([]*metav1.APIResourceList, []*metav1.APIResourceList)
 	GetCrdList() []string
 }
@@ -15,13 +15,17 @@ import (
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/export"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -56,7 +60,20 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
-	chunkSize int
+	// exporter, optional
+	exporter export.Exporter
+
+	// history
+	configMaps  corev1client.ConfigMapsGetter
+	metrics     metrics.MetricsConfigManager
+	historySize int
+
+	chunkSize   int
+	perResource bool
+	// perPolicy partitions policy reports by namespace and policy
+	perPolicy bool
+	// byOwner aggregates the results of resources having the same top level owner
+	byOwner bool
 }
 
 type policyMapEntry struct {
@@ -68,13 +85,30 @@ func keyFunc(obj metav1.Object) cache.ExplicitKey {
 	return cache.ExplicitKey(obj.GetNamespace())
 }
 
+// resourceKeyFunc returns a key identifying the resource a report belongs to,
+// the resource uid is empty for policy reports not created per resource
+func resourceKeyFunc(obj metav1.Object) cache.ExplicitKey {
+	uid := string(reportutils.GetResourceUid(obj))
+	if obj.GetNamespace() == "" {
+		return cache.ExplicitKey(uid)
+	}
+	return cache.ExplicitKey(obj.GetNamespace() + "/" + uid)
+}
+
 func NewController(
 	client versioned.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	metadataCache resource.MetadataCache,
+	exporter export.Exporter,
+	configMaps corev1client.ConfigMapsGetter,
+	metricsConfig metrics.MetricsConfigManager,
+	historySize int,
 	chunkSize int,
+	perResource bool,
+	perPolicy bool,
+	byOwner bool,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
@@ -92,29 +126,53 @@ func NewController(
 		cbgscanrLister: cbgscanrInformer.Lister(),
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		metadataCache:  metadataCache,
+		exporter:       exporter,
+		configMaps:     configMaps,
+		metrics:        metricsConfig,
+		historySize:    historySize,
 		chunkSize:      chunkSize,
+		perResource:    perResource,
+		perPolicy:      perPolicy,
+		byOwner:        byOwner,
+	}
+	keyFunc := keyFunc
+	if perResource {
+		keyFunc = resourceKeyFunc
 	}
 	delay := 15 * time.Second
-	controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, delay, keyFunc)
-	controllerutils.AddDelayedExplicitEventHandlers(logger, cpolrInformer.Informer(), c.queue, delay, keyFunc)
-	controllerutils.AddDelayedExplicitEventHandlers(logger, bgscanrInformer.Informer(), c.queue, delay, keyFunc)
-	controllerutils.AddDelayedExplicitEventHandlers(logger, cbgscanrInformer.Informer(), c.queue, delay, keyFunc)
+	enqueue := func(obj metav1.Object) {
+		c.queue.AddAfter(keyFunc(obj), delay)
+	}
+	if perPolicy {
+		enqueue = func(obj metav1.Object) {
+			c.enqueuePartitions(obj, delay)
+		}
+		addPartitionEventHandlers(polrInformer.Informer(), enqueue)
+		addPartitionEventHandlers(cpolrInformer.Informer(), enqueue)
+		addPartitionEventHandlers(bgscanrInformer.Informer(), enqueue)
+		addPartitionEventHandlers(cbgscanrInformer.Informer(), enqueue)
+	} else {
+		controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, delay, keyFunc)
+		controllerutils.AddDelayedExplicitEventHandlers(logger, cpolrInformer.Informer(), c.queue, delay, keyFunc)
+		controllerutils.AddDelayedExplicitEventHandlers(logger, bgscanrInformer.Informer(), c.queue, delay, keyFunc)
+		controllerutils.AddDelayedExplicitEventHandlers(logger, cbgscanrInformer.Informer(), c.queue, delay, keyFunc)
+	}
 	enqueueFromAdmr := func(obj metav1.Object) {
 		// no need to consider non aggregated reports
 		if controllerutils.HasLabel(obj, reportutils.LabelAggregatedReport) {
-			c.queue.AddAfter(keyFunc(obj), delay)
+			enqueue(obj)
 		}
 	}
 	controllerutils.AddEventHandlersT(
 		admrInformer.Informer(),
 		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(_, obj metav1.Object) { enqueueFromAdmr(obj) },
+		func(old, obj metav1.Object) { enqueueFromAdmr(old); enqueueFromAdmr(obj) },
 		func(obj metav1.Object) { enqueueFromAdmr(obj) },
 	)
 	controllerutils.AddEventHandlersT(
 		cadmrInformer.Informer(),
 		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(_, obj metav1.Object) { enqueueFromAdmr(obj) },
+		func(old, obj metav1.Object) { enqueueFromAdmr(old); enqueueFromAdmr(obj) },
 		func(obj metav1.Object) { enqueueFromAdmr(obj) },
 	)
 	return &c
@@ -124,13 +182,19 @@ func (c *controller) Run(ctx context.Context, workers int) {
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
-func (c *controller) mergeAdmissionReports(ctx context.Context, namespace string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
+// mergeAdmissionReports merges the aggregated admission reports of a namespace, the selector optionally
+// restricts the merged reports further
+func (c *controller) mergeAdmissionReports(ctx context.Context, namespace, selector string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
+	// no need to consider non aggregated reports
+	labelSelector := reportutils.LabelAggregatedReport
+	if selector != "" {
+		labelSelector += "," + selector
+	}
 	if namespace == "" {
 		next := ""
 		for {
 			cadms, err := c.client.KyvernoV1alpha2().ClusterAdmissionReports().List(ctx, metav1.ListOptions{
-				// no need to consider non aggregated reports
-				LabelSelector: reportutils.LabelAggregatedReport,
+				LabelSelector: labelSelector,
 				Limit:         mergeLimit,
 				Continue:      next,
 			})
@@ -149,8 +213,7 @@ func (c *controller) mergeAdmissionReports(ctx context.Context, namespace string
 		next := ""
 		for {
 			adms, err := c.client.KyvernoV1alpha2().AdmissionReports(namespace).List(ctx, metav1.ListOptions{
-				// no need to consider non aggregated reports
-				LabelSelector: reportutils.LabelAggregatedReport,
+				LabelSelector: labelSelector,
 				Limit:         mergeLimit,
 				Continue:      next,
 			})
@@ -168,13 +231,16 @@ func (c *controller) mergeAdmissionReports(ctx context.Context, namespace string
 	}
 }
 
-func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
+// mergeBackgroundScanReports merges the background scan reports of a namespace, the selector optionally
+// restricts the merged reports
+func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace, selector string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
 	if namespace == "" {
 		next := ""
 		for {
 			cbgscans, err := c.client.KyvernoV1alpha2().ClusterBackgroundScanReports().List(ctx, metav1.ListOptions{
-				Limit:    mergeLimit,
-				Continue: next,
+				LabelSelector: selector,
+				Limit:         mergeLimit,
+				Continue:      next,
 			})
 			if err != nil {
 				return err
@@ -191,8 +257,9 @@ func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace s
 		next := ""
 		for {
 			bgscans, err := c.client.KyvernoV1alpha2().BackgroundScanReports(namespace).List(ctx, metav1.ListOptions{
-				Limit:    mergeLimit,
-				Continue: next,
+				LabelSelector: selector,
+				Limit:         mergeLimit,
+				Continue:      next,
 			})
 			if err != nil {
 				return err
@@ -217,6 +284,9 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 				reportutils.SetPolicyLabel(report, policy.policy)
 			}
 		}
+		if c.perPolicy {
+			setPolicyOwner(report, policyMap, results...)
+		}
 		return reportutils.CreateReport(ctx, report, c.client)
 	}
 	after := reportutils.DeepCopy(report)
@@ -228,6 +298,9 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 			reportutils.SetPolicyLabel(after, policy.policy)
 		}
 	}
+	if c.perPolicy {
+		setPolicyOwner(after, policyMap, results...)
+	}
 	reportutils.SetResults(after, results...)
 	if reflect.DeepEqual(report, after) {
 		return after, nil
@@ -323,16 +396,17 @@ func (c *controller) buildReportsResults(ctx context.Context, namespace string)
 		return nil, nil, err
 	}
 	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
-	if err := c.mergeAdmissionReports(ctx, namespace, policyMap, merged); err != nil {
+	if err := c.mergeAdmissionReports(ctx, namespace, "", policyMap, merged); err != nil {
 		return nil, nil, err
 	}
-	if err := c.mergeBackgroundScanReports(ctx, namespace, policyMap, merged); err != nil {
+	if err := c.mergeBackgroundScanReports(ctx, namespace, "", policyMap, merged); err != nil {
 		return nil, nil, err
 	}
 	var results []policyreportv1alpha2.PolicyReportResult
 	for _, result := range merged {
 		results = append(results, result)
 	}
+	c.setOwners(ctx, results)
 	return results, policyMap, nil
 }
 
@@ -363,18 +437,55 @@ func (c *controller) getPolicyReports(ctx context.Context, namespace string) ([]
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	if c.perResource {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		return c.reconcileResourceReport(ctx, logger, namespace, types.UID(name))
+	}
+	if c.perPolicy {
+		namespace, label, err := splitPartitionKey(key)
+		if err != nil {
+			return err
+		}
+		return c.reconcilePartition(ctx, logger, namespace, label)
+	}
 	results, policyMap, err := c.buildReportsResults(ctx, key)
 	if err != nil {
 		return err
 	}
+	if c.byOwner {
+		results = aggregateByOwner(results)
+	}
 	policyReports, err := c.getPolicyReports(ctx, key)
 	if err != nil {
 		return err
 	}
 	actual := map[string]kyvernov1alpha2.ReportInterface{}
+	var previous []policyreportv1alpha2.PolicyReportResult
 	for _, report := range policyReports {
 		actual[report.GetName()] = report
+		previous = append(previous, report.GetResults()...)
 	}
+	transitions := trackTransitions(previous, results, time.Now())
+	expected, err := c.reconcileReports(ctx, logger, policyMap, actual, key, results)
+	if err != nil {
+		return err
+	}
+	if err := c.cleanReports(ctx, actual, expected); err != nil {
+		return err
+	}
+	c.recordTransitions(ctx, logger, key, transitions)
+	if c.exporter != nil {
+		c.exporter.Export(export.Partition{Namespace: key}, results)
+	}
+	return nil
+}
+
+// reconcileReports splits the results of a namespace by policy and reconciles the corresponding
+// policy reports, reports having more results than the chunk size are split in several reports
+func (c *controller) reconcileReports(ctx context.Context, logger logr.Logger, policyMap map[string]policyMapEntry, actual map[string]kyvernov1alpha2.ReportInterface, namespace string, results []policyreportv1alpha2.PolicyReportResult) ([]kyvernov1alpha2.ReportInterface, error) {
 	splitReports := reportutils.SplitResultsByPolicy(logger, results)
 	var expected []kyvernov1alpha2.ReportInterface
 	chunkSize := c.chunkSize
@@ -391,12 +502,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 			if i > 0 {
 				name = fmt.Sprintf("%s-%d", name, i/chunkSize)
 			}
-			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, results[i:end]...)
+			report, err := c.reconcileReport(ctx, policyMap, actual[name], namespace, name, results[i:end]...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			expected = append(expected, report)
 		}
 	}
-	return c.cleanReports(ctx, actual, expected)
+	return expected, nil
 }
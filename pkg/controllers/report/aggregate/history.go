@@ -0,0 +1,199 @@
+package aggregate
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/config"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	historyKey = "history.json"
+	// historyNamespaceLabel holds the report namespace of a history config map
+	historyNamespaceLabel = "audit.kyverno.io/history.namespace"
+	// noResult is the result used for transitions of results appearing or disappearing
+	noResult = "none"
+)
+
+// transition is a change of the result of a policy rule on a resource
+type transition struct {
+	Time     metav1.Time            `json:"time"`
+	Policy   string                 `json:"policy"`
+	Rule     string                 `json:"rule"`
+	Resource corev1.ObjectReference `json:"resource"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+}
+
+func resultKey(result policyreportv1alpha2.PolicyReportResult) string {
+	key := result.Policy + "/" + result.Rule
+	if len(result.Resources) != 0 {
+		key += "/" + string(result.Resources[0].UID)
+	}
+	return key
+}
+
+func resultResource(result policyreportv1alpha2.PolicyReportResult) corev1.ObjectReference {
+	if len(result.Resources) != 0 {
+		return result.Resources[0]
+	}
+	return corev1.ObjectReference{}
+}
+
+func timestampToTime(timestamp metav1.Timestamp) time.Time {
+	return time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
+}
+
+// trackTransitions carries the first seen and last transition timestamps of the previous results over to
+// the current results, and returns the transitions between previous and current results
+func trackTransitions(previous, current []policyreportv1alpha2.PolicyReportResult, now time.Time) []transition {
+	before := map[string]policyreportv1alpha2.PolicyReportResult{}
+	for _, result := range previous {
+		before[resultKey(result)] = result
+	}
+	seen := sets.NewString()
+	var transitions []transition
+	for i := range current {
+		result := &current[i]
+		key := resultKey(*result)
+		seen.Insert(key)
+		firstSeen := timestampToTime(result.Timestamp).UTC().Format(time.RFC3339)
+		if prev, ok := before[key]; ok {
+			if value, ok := prev.Properties[reportutils.FirstSeenProperty]; ok {
+				firstSeen = value
+			} else {
+				firstSeen = timestampToTime(prev.Timestamp).UTC().Format(time.RFC3339)
+			}
+			if prev.Result == result.Result {
+				result.Timestamp = prev.Timestamp
+			} else {
+				transitions = append(transitions, newTransition(*result, string(prev.Result), string(result.Result), timestampToTime(result.Timestamp)))
+			}
+		} else {
+			transitions = append(transitions, newTransition(*result, noResult, string(result.Result), timestampToTime(result.Timestamp)))
+		}
+		properties := map[string]string{}
+		for k, v := range result.Properties {
+			properties[k] = v
+		}
+		properties[reportutils.FirstSeenProperty] = firstSeen
+		result.Properties = properties
+	}
+	for key, prev := range before {
+		if !seen.Has(key) {
+			transitions = append(transitions, newTransition(prev, string(prev.Result), noResult, now))
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].Time.Before(&transitions[j].Time)
+	})
+	return transitions
+}
+
+func newTransition(result policyreportv1alpha2.PolicyReportResult, from, to string, at time.Time) transition {
+	return transition{
+		Time:     metav1.NewTime(at.UTC()),
+		Policy:   result.Policy,
+		Rule:     result.Rule,
+		Resource: resultResource(result),
+		From:     from,
+		To:       to,
+	}
+}
+
+// recordTransitions exposes transitions as metrics and appends them to the namespace history if enabled,
+// failures are logged only as the transitions are already reflected in reports
+func (c *controller) recordTransitions(ctx context.Context, logger logr.Logger, namespace string, transitions []transition) {
+	if len(transitions) == 0 {
+		return
+	}
+	if c.metrics != nil {
+		for _, t := range transitions {
+			if !c.metrics.Config().CheckNamespace(t.Resource.Namespace) {
+				continue
+			}
+			policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(t.Policy)
+			if err != nil {
+				logger.Error(err, "failed to decode policy name", "key", t.Policy)
+				continue
+			}
+			c.metrics.RecordPolicyResultTransitions(ctx, policyNamespace, policyName, t.Rule, t.Resource.Kind, t.Resource.Namespace, t.From, t.To)
+		}
+	}
+	if c.historySize > 0 && c.configMaps != nil {
+		if err := c.appendHistory(ctx, namespace, transitions); err != nil {
+			logger.Error(err, "failed to update report history")
+		}
+	}
+}
+
+// historyConfigMap returns the name of the config map holding the history of a report namespace,
+// history config maps all live in the kyverno namespace
+func historyConfigMap(namespace string) string {
+	if namespace == "" {
+		return "kyverno-report-history-cluster"
+	}
+	return "kyverno-report-history-ns-" + namespace
+}
+
+func (c *controller) appendHistory(ctx context.Context, namespace string, transitions []transition) error {
+	cmNamespace, cmName := config.KyvernoNamespace(), historyConfigMap(namespace)
+	client := c.configMaps.ConfigMaps(cmNamespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := client.Get(ctx, cmName, metav1.GetOptions{})
+		found := err == nil
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cmName,
+					Namespace: cmNamespace,
+					Labels: map[string]string{
+						kyvernov1.LabelAppManagedBy: kyvernov1.ValueKyvernoApp,
+						historyNamespaceLabel:       namespace,
+					},
+				},
+			}
+		} else {
+			cm = cm.DeepCopy()
+		}
+		var history []transition
+		if data, ok := cm.Data[historyKey]; ok {
+			if err := json.Unmarshal([]byte(data), &history); err != nil {
+				return err
+			}
+		}
+		history = append(history, transitions...)
+		if len(history) > c.historySize {
+			history = history[len(history)-c.historySize:]
+		}
+		data, err := json.Marshal(history)
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[historyKey] = string(data)
+		if found {
+			_, err = client.Update(ctx, cm, metav1.UpdateOptions{})
+		} else {
+			_, err = client.Create(ctx, cm, metav1.CreateOptions{})
+		}
+		return err
+	})
+}
@@ -0,0 +1,86 @@
+package aggregate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/config"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newResult(uid types.UID, result policyreportv1alpha2.PolicyResult, at time.Time) policyreportv1alpha2.PolicyReportResult {
+	return policyreportv1alpha2.PolicyReportResult{
+		Policy:    "pol",
+		Rule:      "rule",
+		Result:    result,
+		Timestamp: metav1.Timestamp{Seconds: at.Unix()},
+		Resources: []corev1.ObjectReference{{Kind: "Pod", Namespace: "test", Name: string(uid), UID: uid}},
+	}
+}
+
+func Test_TrackTransitions(t *testing.T) {
+	t0 := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+	current := []policyreportv1alpha2.PolicyReportResult{
+		newResult("a", policyreportv1alpha2.StatusFail, t0),
+		newResult("b", policyreportv1alpha2.StatusFail, t0),
+		newResult("c", policyreportv1alpha2.StatusPass, t0),
+	}
+	transitions := trackTransitions(nil, current, t0)
+	assert.Equal(t, len(transitions), 3)
+	assert.Equal(t, transitions[0].From, noResult)
+	assert.Equal(t, current[0].Properties[reportutils.FirstSeenProperty], "2022-12-01T00:00:00Z")
+	// a keeps failing, b gets fixed and c disappears
+	previous := current
+	current = []policyreportv1alpha2.PolicyReportResult{
+		newResult("a", policyreportv1alpha2.StatusFail, t1),
+		newResult("b", policyreportv1alpha2.StatusPass, t1),
+	}
+	transitions = trackTransitions(previous, current, t2)
+	assert.Equal(t, len(transitions), 2)
+	assert.Equal(t, transitions[0].Resource.Name, "b")
+	assert.Equal(t, transitions[0].From, "fail")
+	assert.Equal(t, transitions[0].To, "pass")
+	assert.Equal(t, transitions[1].Resource.Name, "c")
+	assert.Equal(t, transitions[1].From, "pass")
+	assert.Equal(t, transitions[1].To, noResult)
+	assert.Equal(t, transitions[1].Time.Time, t2)
+	// unchanged results keep their last transition time
+	assert.Equal(t, current[0].Timestamp.Seconds, t0.Unix())
+	assert.Equal(t, current[1].Timestamp.Seconds, t1.Unix())
+	assert.Equal(t, current[1].Properties[reportutils.FirstSeenProperty], "2022-12-01T00:00:00Z")
+}
+
+func Test_AppendHistory(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	c := controller{configMaps: client.CoreV1(), historySize: 2}
+	t0 := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	for i, uid := range []types.UID{"a", "b", "c"} {
+		transitions := trackTransitions(nil, []policyreportv1alpha2.PolicyReportResult{
+			newResult(uid, policyreportv1alpha2.StatusFail, t0.Add(time.Duration(i)*time.Hour)),
+		}, t0)
+		c.recordTransitions(ctx, logr.Discard(), "test", transitions)
+	}
+	cm, err := client.CoreV1().ConfigMaps(config.KyvernoNamespace()).Get(ctx, "kyverno-report-history-ns-test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, cm.Labels[historyNamespaceLabel], "test")
+	_, err = client.CoreV1().ConfigMaps("test").Get(ctx, "kyverno-report-history-ns-test", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	var history []transition
+	assert.NilError(t, json.Unmarshal([]byte(cm.Data[historyKey]), &history))
+	assert.Equal(t, len(history), 2)
+	assert.Equal(t, history[0].Resource.Name, "b")
+	assert.Equal(t, history[1].Resource.Name, "c")
+}
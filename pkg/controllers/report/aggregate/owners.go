@@ -0,0 +1,97 @@
+package aggregate
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resultSeverity orders results from the least to the most severe
+var resultSeverity = map[policyreportv1alpha2.PolicyResult]int{
+	policyreportv1alpha2.StatusSkip:  0,
+	policyreportv1alpha2.StatusPass:  1,
+	policyreportv1alpha2.StatusWarn:  2,
+	policyreportv1alpha2.StatusFail:  3,
+	policyreportv1alpha2.StatusError: 4,
+}
+
+// setOwners adds the top level owner of their resource to the results, owners are resolved once per resource
+func (c *controller) setOwners(ctx context.Context, results []policyreportv1alpha2.PolicyReportResult) {
+	if c.metadataCache == nil {
+		return
+	}
+	owners := map[types.UID]*metav1.OwnerReference{}
+	for i := range results {
+		if len(results[i].Resources) == 0 {
+			continue
+		}
+		uid := results[i].Resources[0].UID
+		owner, resolved := owners[uid]
+		if !resolved {
+			if ref, ok := c.metadataCache.GetTopLevelOwner(ctx, uid); ok {
+				owner = &ref
+			}
+			owners[uid] = owner
+		}
+		if owner != nil {
+			reportutils.SetOwnerProperties(&results[i], *owner)
+		}
+	}
+}
+
+type ownerResult struct {
+	result    policyreportv1alpha2.PolicyReportResult
+	instances int
+}
+
+// aggregateByOwner replaces the results of resources having an owner by a single result per owner and rule,
+// it holds the most severe result of the owned resources, the latest timestamp and the number of resources
+func aggregateByOwner(results []policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	var aggregated []policyreportv1alpha2.PolicyReportResult
+	byOwner := map[string]*ownerResult{}
+	for _, result := range results {
+		owner, ok := reportutils.GetOwnerReference(result)
+		if !ok {
+			aggregated = append(aggregated, result)
+			continue
+		}
+		key := result.Policy + "/" + result.Rule + "/" + string(owner.UID)
+		entry, exists := byOwner[key]
+		if !exists {
+			entry = &ownerResult{result: result}
+			byOwner[key] = entry
+		} else if resultSeverity[result.Result] > resultSeverity[entry.result.Result] {
+			timestamp := entry.result.Timestamp
+			entry.result = result
+			if timestamp.Seconds > result.Timestamp.Seconds {
+				entry.result.Timestamp = timestamp
+			}
+		} else if result.Timestamp.Seconds > entry.result.Timestamp.Seconds {
+			entry.result.Timestamp = result.Timestamp
+		}
+		entry.result.Resources = []corev1.ObjectReference{owner}
+		entry.instances++
+	}
+	keys := make([]string, 0, len(byOwner))
+	for key := range byOwner {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entry := byOwner[key]
+		properties := map[string]string{}
+		for k, v := range entry.result.Properties {
+			properties[k] = v
+		}
+		properties[reportutils.InstancesProperty] = strconv.Itoa(entry.instances)
+		entry.result.Properties = properties
+		aggregated = append(aggregated, entry.result)
+	}
+	return aggregated
+}
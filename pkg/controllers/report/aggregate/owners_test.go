@@ -0,0 +1,80 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeMetadataCache struct {
+	resource.MetadataCache
+	owners  map[types.UID]metav1.OwnerReference
+	lookups map[types.UID]int
+}
+
+func (c fakeMetadataCache) GetTopLevelOwner(_ context.Context, uid types.UID) (metav1.OwnerReference, bool) {
+	if c.lookups != nil {
+		c.lookups[uid]++
+	}
+	owner, ok := c.owners[uid]
+	return owner, ok
+}
+
+func Test_SetOwners(t *testing.T) {
+	t0 := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	cache := fakeMetadataCache{
+		owners:  map[types.UID]metav1.OwnerReference{"a": {APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "app"}},
+		lookups: map[types.UID]int{},
+	}
+	c := controller{metadataCache: cache}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		newResult("a", policyreportv1alpha2.StatusPass, t0),
+		newResult("a", policyreportv1alpha2.StatusFail, t0),
+		newResult("c", policyreportv1alpha2.StatusPass, t0),
+		newResult("c", policyreportv1alpha2.StatusPass, t0),
+	}
+	c.setOwners(context.TODO(), results)
+	// owners are resolved once per resource, including resources without owner
+	assert.DeepEqual(t, cache.lookups, map[types.UID]int{"a": 1, "c": 1})
+	assert.Equal(t, results[1].Properties[reportutils.OwnerUIDProperty], "app")
+	_, ok := results[3].Properties[reportutils.OwnerUIDProperty]
+	assert.Assert(t, !ok)
+}
+
+func Test_AggregateByOwner(t *testing.T) {
+	t0 := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	deployment := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "app"}
+	c := controller{metadataCache: fakeMetadataCache{owners: map[types.UID]metav1.OwnerReference{
+		"a": deployment,
+		"b": deployment,
+	}}}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		newResult("a", policyreportv1alpha2.StatusPass, t0.Add(time.Hour)),
+		newResult("b", policyreportv1alpha2.StatusFail, t0),
+		newResult("c", policyreportv1alpha2.StatusPass, t0),
+	}
+	results[1].Message = "failed"
+	c.setOwners(context.TODO(), results)
+	assert.Equal(t, results[0].Properties[reportutils.OwnerKindProperty], "Deployment")
+	assert.Equal(t, results[1].Properties[reportutils.OwnerUIDProperty], "app")
+	_, ok := results[2].Properties[reportutils.OwnerUIDProperty]
+	assert.Assert(t, !ok)
+	aggregated := aggregateByOwner(results)
+	assert.Equal(t, len(aggregated), 2)
+	assert.Equal(t, aggregated[0].Resources[0].Name, "c")
+	owned := aggregated[1]
+	assert.Equal(t, owned.Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusFail))
+	assert.Equal(t, owned.Message, "failed")
+	assert.Equal(t, owned.Timestamp.Seconds, t0.Add(time.Hour).Unix())
+	assert.Equal(t, len(owned.Resources), 1)
+	assert.Equal(t, owned.Resources[0].Kind, "Deployment")
+	assert.Equal(t, owned.Resources[0].Namespace, "test")
+	assert.Equal(t, owned.Properties[reportutils.InstancesProperty], "2")
+}
@@ -0,0 +1,158 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers/report/export"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// partitionKey returns the queue key of the partition of a policy in a namespace,
+// the policy is identified by its report label
+func partitionKey(namespace, label string) cache.ExplicitKey {
+	return cache.ExplicitKey(namespace + "/" + label)
+}
+
+func splitPartitionKey(key string) (string, string, error) {
+	namespace, label, ok := strings.Cut(key, "/")
+	if !ok || !reportutils.IsPolicyLabel(label) {
+		return "", "", fmt.Errorf("invalid partition key: %s", key)
+	}
+	return namespace, label, nil
+}
+
+// partitionKeys returns the keys of the partitions a report contributes to, one per policy label
+func partitionKeys(obj metav1.Object) []cache.ExplicitKey {
+	var keys []cache.ExplicitKey
+	for label := range obj.GetLabels() {
+		if reportutils.IsPolicyLabel(label) {
+			keys = append(keys, partitionKey(obj.GetNamespace(), label))
+		}
+	}
+	return keys
+}
+
+func (c *controller) enqueuePartitions(obj metav1.Object, delay time.Duration) {
+	for _, key := range partitionKeys(obj) {
+		c.queue.AddAfter(key, delay)
+	}
+}
+
+// addPartitionEventHandlers enqueues the partitions of reports, on updates the partitions of the
+// previous version are enqueued too so that partitions a report doesn't contribute to anymore are updated
+func addPartitionEventHandlers(informer cache.SharedInformer, enqueue func(metav1.Object)) {
+	controllerutils.AddEventHandlersT(
+		informer,
+		func(obj metav1.Object) { enqueue(obj) },
+		func(old, obj metav1.Object) { enqueue(old); enqueue(obj) },
+		func(obj metav1.Object) { enqueue(obj) },
+	)
+}
+
+// setPolicyOwner sets the policy of the results as the owner of a partition report,
+// the report is then garbage collected when the policy is deleted
+func setPolicyOwner(report kyvernov1alpha2.ReportInterface, policyMap map[string]policyMapEntry, results ...policyreportv1alpha2.PolicyReportResult) {
+	if len(results) == 0 {
+		return
+	}
+	policy := policyMap[results[0].Policy].policy
+	if policy == nil {
+		return
+	}
+	kind := "ClusterPolicy"
+	if policy.IsNamespaced() {
+		kind = "Policy"
+	}
+	controllerutils.SetOwner(report, kyvernov1.SchemeGroupVersion.String(), kind, policy.GetName(), policy.GetUID())
+}
+
+// reconcilePartition maintains the policy reports of a single policy in a namespace, only the source
+// reports labelled with the policy are merged, the partition reports are deleted when no result is left
+func (c *controller) reconcilePartition(ctx context.Context, logger logr.Logger, namespace, label string) error {
+	policyName, err := reportutils.PolicyNameFromLabel(namespace, label)
+	if err != nil {
+		return err
+	}
+	policyMap, err := c.createPolicyMap()
+	if err != nil {
+		return err
+	}
+	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
+	if err := c.mergeAdmissionReports(ctx, namespace, label, policyMap, merged); err != nil {
+		return err
+	}
+	if err := c.mergeBackgroundScanReports(ctx, namespace, label, policyMap, merged); err != nil {
+		return err
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range merged {
+		if result.Policy == policyName {
+			results = append(results, result)
+		}
+	}
+	c.setOwners(ctx, results)
+	if c.byOwner {
+		results = aggregateByOwner(results)
+	}
+	policyReports, err := c.getPartitionReports(ctx, namespace, label)
+	if err != nil {
+		return err
+	}
+	actual := map[string]kyvernov1alpha2.ReportInterface{}
+	var previous []policyreportv1alpha2.PolicyReportResult
+	for _, report := range policyReports {
+		actual[report.GetName()] = report
+		previous = append(previous, report.GetResults()...)
+	}
+	transitions := trackTransitions(previous, results, time.Now())
+	expected, err := c.reconcileReports(ctx, logger, policyMap, actual, namespace, results)
+	if err != nil {
+		return err
+	}
+	if err := c.cleanReports(ctx, actual, expected); err != nil {
+		return err
+	}
+	c.recordTransitions(ctx, logger, namespace, transitions)
+	if c.exporter != nil {
+		c.exporter.Export(export.Partition{Namespace: namespace, Policy: label}, results)
+	}
+	return nil
+}
+
+// getPartitionReports returns the policy reports of a partition
+func (c *controller) getPartitionReports(ctx context.Context, namespace, label string) ([]kyvernov1alpha2.ReportInterface, error) {
+	var reports []kyvernov1alpha2.ReportInterface
+	options := metav1.ListOptions{LabelSelector: label}
+	if namespace == "" {
+		list, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if controllerutils.IsManagedByKyverno(&list.Items[i]) {
+				reports = append(reports, &list.Items[i])
+			}
+		}
+	} else {
+		list, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			if controllerutils.IsManagedByKyverno(&list.Items[i]) {
+				reports = append(reports, &list.Items[i])
+			}
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,79 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_ReconcilePartition(t *testing.T) {
+	ctx := context.TODO()
+	var cpols []*kyvernov1.ClusterPolicy
+	for _, name := range []string{"pol1", "pol2"} {
+		cpols = append(cpols, &kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name), ResourceVersion: "1"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{{Name: "rule"}}},
+		})
+	}
+	bgscanr := reportutils.NewBackgroundScanReport("test", "uid", schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "nginx", "uid")
+	for _, cpol := range cpols {
+		reportutils.SetPolicyLabel(bgscanr, cpol)
+	}
+	reportutils.SetResults(bgscanr,
+		policyreportv1alpha2.PolicyReportResult{Policy: "pol1", Rule: "rule", Result: policyreportv1alpha2.StatusFail},
+		policyreportv1alpha2.PolicyReportResult{Policy: "pol2", Rule: "rule", Result: policyreportv1alpha2.StatusPass},
+	)
+	client := fake.NewSimpleClientset(bgscanr.(*kyvernov1alpha2.BackgroundScanReport))
+	factory := kyvernoinformer.NewSharedInformerFactory(client, 0)
+	for _, cpol := range cpols {
+		assert.NilError(t, factory.Kyverno().V1().ClusterPolicies().Informer().GetIndexer().Add(cpol))
+	}
+	c := controller{
+		client:     client,
+		polLister:  factory.Kyverno().V1().Policies().Lister(),
+		cpolLister: factory.Kyverno().V1().ClusterPolicies().Lister(),
+		perPolicy:  true,
+	}
+	keys := partitionKeys(bgscanr)
+	assert.Equal(t, len(keys), 2)
+	namespace, label, err := splitPartitionKey(string(partitionKey("test", reportutils.PolicyLabel(cpols[0]))))
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "test")
+	assert.Equal(t, label, "cpol.kyverno.io/pol1")
+	// only the partition of the reconciled policy is created
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test/cpol.kyverno.io/pol1", "", ""))
+	report, err := client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "cpol-pol1", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.Results), 1)
+	assert.Equal(t, report.Results[0].Policy, "pol1")
+	assert.Equal(t, len(report.GetOwnerReferences()), 1)
+	assert.Equal(t, report.GetOwnerReferences()[0].Kind, "ClusterPolicy")
+	assert.Equal(t, string(report.GetOwnerReferences()[0].UID), "uid-pol1")
+	assert.Assert(t, controllerutils.HasLabel(report, reportutils.PolicyLabel(cpols[0])))
+	_, err = client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "cpol-pol2", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// the partition is deleted once the source report doesn't contribute to it anymore
+	reportutils.SetResults(bgscanr, policyreportv1alpha2.PolicyReportResult{Policy: "pol2", Rule: "rule", Result: policyreportv1alpha2.StatusPass})
+	bgscanr.SetLabels(nil)
+	reportutils.SetPolicyLabel(bgscanr, cpols[1])
+	_, err = client.KyvernoV1alpha2().BackgroundScanReports("test").Update(ctx, bgscanr.(*kyvernov1alpha2.BackgroundScanReport), metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test/cpol.kyverno.io/pol1", "", ""))
+	_, err = client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "cpol-pol1", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// invalid keys are rejected
+	assert.ErrorContains(t, c.reconcile(ctx, logr.Discard(), "test", "", ""), "invalid partition key")
+}
@@ -0,0 +1,159 @@
+package aggregate
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// reconcileResourceReport maintains the policy report of a single resource, the report has the
+// same name as the resource uid and is owned by the resource so that it is garbage collected with it
+func (c *controller) reconcileResourceReport(ctx context.Context, logger logr.Logger, namespace string, uid types.UID) error {
+	if uid == "" {
+		return c.cleanNamespaceReports(ctx, namespace)
+	}
+	policyMap, err := c.createPolicyMap()
+	if err != nil {
+		return err
+	}
+	reports, err := c.getResourceReports(ctx, namespace, string(uid))
+	if err != nil {
+		return err
+	}
+	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
+	mergeReports(policyMap, merged, reports...)
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range merged {
+		results = append(results, result)
+	}
+	c.setOwners(ctx, results)
+	report, err := c.getResourcePolicyReport(ctx, namespace, string(uid))
+	if err != nil {
+		return err
+	}
+	var previous []policyreportv1alpha2.PolicyReportResult
+	if report != nil {
+		previous = report.GetResults()
+	}
+	transitions := trackTransitions(previous, results, time.Now())
+	if len(results) == 0 {
+		if report == nil {
+			return nil
+		}
+		logger.V(4).Info("deleting resource policy report", "uid", uid)
+		if err := reportutils.DeleteReport(ctx, report, c.client); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		c.recordTransitions(ctx, logger, namespace, transitions)
+		return nil
+	}
+	found := report != nil
+	if !found {
+		report = reportutils.NewPolicyReport(namespace, string(uid))
+	}
+	after := report
+	if found {
+		after = reportutils.DeepCopy(report)
+	}
+	owner := results[0].Resources[0]
+	controllerutils.SetOwner(after, owner.APIVersion, owner.Kind, owner.Name, owner.UID)
+	after.SetLabels(nil)
+	reportutils.SetManagedByKyvernoLabel(after)
+	reportutils.SetResourceLabels(after, uid)
+	for _, result := range results {
+		policy := policyMap[result.Policy]
+		if policy.policy != nil {
+			reportutils.SetPolicyLabel(after, policy.policy)
+		}
+	}
+	reportutils.SetResultLabels(after, results...)
+	reportutils.SetResults(after, results...)
+	if !found {
+		_, err = reportutils.CreateReport(ctx, after, c.client)
+	} else if !reflect.DeepEqual(report, after) {
+		_, err = reportutils.UpdateReport(ctx, after, c.client)
+	}
+	if err != nil {
+		return err
+	}
+	c.recordTransitions(ctx, logger, namespace, transitions)
+	return nil
+}
+
+// getResourceReports returns the aggregated admission report and the background scan report of a resource
+func (c *controller) getResourceReports(ctx context.Context, namespace, name string) ([]kyvernov1alpha2.ReportInterface, error) {
+	var reports []kyvernov1alpha2.ReportInterface
+	var admr, bgscanr kyvernov1alpha2.ReportInterface
+	var err error
+	if namespace == "" {
+		admr, err = c.client.KyvernoV1alpha2().ClusterAdmissionReports().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		admr, err = c.client.KyvernoV1alpha2().AdmissionReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else if controllerutils.HasLabel(admr, reportutils.LabelAggregatedReport) {
+		reports = append(reports, admr)
+	}
+	if namespace == "" {
+		bgscanr, err = c.client.KyvernoV1alpha2().ClusterBackgroundScanReports().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		bgscanr, err = c.client.KyvernoV1alpha2().BackgroundScanReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		reports = append(reports, bgscanr)
+	}
+	return reports, nil
+}
+
+func (c *controller) getResourcePolicyReport(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error) {
+	var report kyvernov1alpha2.ReportInterface
+	var err error
+	if namespace == "" {
+		report, err = c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		report, err = c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !controllerutils.IsManagedByKyverno(report) {
+		return nil, nil
+	}
+	return report, nil
+}
+
+// cleanNamespaceReports deletes the policy reports that don't belong to a single resource,
+// they were created before switching to per resource reports
+func (c *controller) cleanNamespaceReports(ctx context.Context, namespace string) error {
+	reports, err := c.getPolicyReports(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if reportutils.GetResourceUid(report) == "" {
+			if err := reportutils.DeleteReport(ctx, report, c.client); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_ReconcileResourceReport(t *testing.T) {
+	ctx := context.TODO()
+	cpol := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "pol", ResourceVersion: "1"},
+		Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{{Name: "rule"}}},
+	}
+	bgscanr := reportutils.NewBackgroundScanReport("test", "uid", schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "nginx", "uid")
+	reportutils.SetResults(bgscanr, policyreportv1alpha2.PolicyReportResult{
+		Policy: "pol",
+		Rule:   "rule",
+		Result: policyreportv1alpha2.StatusFail,
+	})
+	namespaceReport := reportutils.NewPolicyReport("test", "cpol-pol")
+	client := fake.NewSimpleClientset(bgscanr.(*kyvernov1alpha2.BackgroundScanReport), namespaceReport.(*policyreportv1alpha2.PolicyReport))
+	factory := kyvernoinformer.NewSharedInformerFactory(client, 0)
+	assert.NilError(t, factory.Kyverno().V1().ClusterPolicies().Informer().GetIndexer().Add(cpol))
+	c := controller{
+		client:      client,
+		polLister:   factory.Kyverno().V1().Policies().Lister(),
+		cpolLister:  factory.Kyverno().V1().ClusterPolicies().Lister(),
+		perResource: true,
+	}
+	// explicit keys are enqueued, the namespace and name are not provided by the queue
+	key := string(resourceKeyFunc(bgscanr))
+	assert.Equal(t, key, "test/uid")
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), key, "", ""))
+	report, err := client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "uid", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.GetOwnerReferences()), 1)
+	assert.Equal(t, report.GetOwnerReferences()[0].Name, "nginx")
+	assert.Equal(t, report.GetLabels()[reportutils.LabelResourceUid], "uid")
+	assert.Equal(t, report.GetLabels()[reportutils.ResultLabel(policyreportv1alpha2.StatusFail)], "1")
+	assert.Equal(t, report.GetLabels()[reportutils.PolicyLabel(cpol)], "1")
+	assert.Equal(t, len(report.Results), 1)
+	assert.Equal(t, report.Results[0].Resources[0].Name, "nginx")
+	// namespace wide reports are deleted
+	namespaceKey := string(resourceKeyFunc(namespaceReport))
+	assert.Equal(t, namespaceKey, "test/")
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), namespaceKey, "", ""))
+	_, err = client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "cpol-pol", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// the report is deleted when the resource has no results anymore
+	assert.NilError(t, client.KyvernoV1alpha2().BackgroundScanReports("test").Delete(ctx, "uid", metav1.DeleteOptions{}))
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), key, "", ""))
+	_, err = client.Wgpolicyk8sV1alpha2().PolicyReports("test").Get(ctx, "uid", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
@@ -2,6 +2,8 @@ package background
 
 import (
 	"context"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,14 +19,17 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -37,6 +42,8 @@ const (
 	Workers        = 2
 	ControllerName = "background-scan-controller"
 	maxRetries     = 10
+	// configMapIndex indexes background scan reports by the config maps read when scanning them
+	configMapIndex = "configmaps"
 )
 
 type controller struct {
@@ -52,6 +59,10 @@ type controller struct {
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
 
+	// indexers
+	bgscanrIndexer  cache.Indexer
+	cbgscanrIndexer cache.Indexer
+
 	// queue
 	queue          workqueue.RateLimitingInterface
 	bgscanEnqueue  controllerutils.EnqueueFunc
@@ -61,6 +72,17 @@ type controller struct {
 	metadataCache resource.MetadataCache
 
 	informerCacheResolvers resolvers.ConfigmapResolver
+
+	metrics metrics.MetricsConfigManager
+
+	lock sync.Mutex
+	// stale contains, per report key, the labels of the policies to rescan because a config map they read
+	// changed or their scan interval elapsed
+	stale map[string]sets.String
+	// limiters contains the rate limiters of the policies with a max resources per second, per policy key
+	limiters map[string]policyLimiter
+	// lastScheduled contains the time of the last periodic scan of the policies with an interval, per policy key
+	lastScheduled map[string]time.Time
 }
 
 func NewController(
@@ -73,9 +95,19 @@ func NewController(
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	metricsConfig metrics.MetricsConfigManager,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
+	// config maps are watched without label filtering, policies can read any config map through the client
+	configMaps := metadataFactory.ForResource(corev1.SchemeGroupVersion.WithResource("configmaps"))
+	indexers := cache.Indexers{configMapIndex: configMapIndexFunc}
+	if err := bgscanr.Informer().AddIndexers(indexers); err != nil {
+		logger.Error(err, "failed to add indexers")
+	}
+	if err := cbgscanr.Informer().AddIndexers(indexers); err != nil {
+		logger.Error(err, "failed to add indexers")
+	}
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
 		client:                 client,
@@ -86,14 +118,21 @@ func NewController(
 		bgscanrLister:          bgscanr.Lister(),
 		cbgscanrLister:         cbgscanr.Lister(),
 		nsLister:               nsInformer.Lister(),
+		bgscanrIndexer:         bgscanr.Informer().GetIndexer(),
+		cbgscanrIndexer:        cbgscanr.Informer().GetIndexer(),
 		queue:                  queue,
 		bgscanEnqueue:          controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
 		cbgscanEnqueue:         controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
 		metadataCache:          metadataCache,
 		informerCacheResolvers: informerCacheResolvers,
+		metrics:                metricsConfig,
+		stale:                  map[string]sets.String{},
+		limiters:               map[string]policyLimiter{},
+		lastScheduled:          map[string]time.Time{},
 	}
 	controllerutils.AddEventHandlersT(polInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
 	controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
+	controllerutils.AddEventHandlersT(configMaps.Informer(), c.addConfigMap, c.updateConfigMap, c.deleteConfigMap)
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, res resource.Resource) {
 		// if it's a deletion, nothing to do
 		if eventType == resource.Deleted {
@@ -116,7 +155,7 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.scheduleScans)
 }
 
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
@@ -151,6 +190,75 @@ func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
 	}
 }
 
+func configMapIndexFunc(obj interface{}) ([]string, error) {
+	meta, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, nil
+	}
+	return reportutils.ConfigMapDependencies(meta), nil
+}
+
+func (c *controller) addConfigMap(_ *metav1.PartialObjectMetadata) {
+	// existing config maps are replayed as additions when the informer starts
+	// so only updates and deletions trigger rescans
+}
+
+func (c *controller) updateConfigMap(old, obj *metav1.PartialObjectMetadata) {
+	if old.GetResourceVersion() != obj.GetResourceVersion() {
+		c.enqueueConfigMapDependents(obj)
+	}
+}
+
+func (c *controller) deleteConfigMap(obj *metav1.PartialObjectMetadata) {
+	c.enqueueConfigMapDependents(obj)
+}
+
+// enqueueConfigMapDependents enqueues the reports of the resources scanned by policies that read the config map,
+// these policies are marked as stale so that they are rescanned even if the resource and policies didn't change
+func (c *controller) enqueueConfigMapDependents(cm metav1.Object) {
+	cmKey := cm.GetNamespace() + "/" + cm.GetName()
+	for _, indexer := range []cache.Indexer{c.bgscanrIndexer, c.cbgscanrIndexer} {
+		objs, err := indexer.ByIndex(configMapIndex, cmKey)
+		if err != nil {
+			logger.Error(err, "failed to list reports", "configmap", cmKey)
+			continue
+		}
+		for _, obj := range objs {
+			meta := obj.(metav1.Object)
+			var labels []string
+			for label, configMaps := range reportutils.GetConfigMapDependencies(meta) {
+				if sets.NewString(configMaps...).Has(cmKey) {
+					labels = append(labels, label)
+				}
+			}
+			key, err := cache.MetaNamespaceKeyFunc(meta)
+			if err != nil {
+				logger.Error(err, "failed to compute key")
+				continue
+			}
+			c.markStale(key, labels...)
+			c.queue.Add(key)
+		}
+	}
+}
+
+func (c *controller) markStale(key string, labels ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.stale[key] == nil {
+		c.stale[key] = sets.NewString()
+	}
+	c.stale[key].Insert(labels...)
+}
+
+func (c *controller) takeStale(key string) sets.String {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stale := c.stale[key]
+	delete(c.stale, key)
+	return stale
+}
+
 func (c *controller) enqueue(selector labels.Selector) error {
 	bgscans, err := c.bgscanrLister.List(selector)
 	if err != nil {
@@ -201,7 +309,7 @@ func (c *controller) fetchPolicies(logger logr.Logger, namespace string) ([]kyve
 	return policies, nil
 }
 
-func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource) error {
+func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource, stale sets.String) error {
 	namespace := meta.GetNamespace()
 	metaLabels := meta.GetLabels()
 	// load all policies
@@ -229,6 +337,7 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 			return nil
 		}
 		report := reportutils.DeepCopy(before)
+		delete(report.GetLabels(), reportutils.LabelScanRequested)
 		resource, err := c.client.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, resource.Namespace, resource.Name)
 		if err != nil {
 			return err
@@ -245,16 +354,26 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 			}
 			nsLabels = ns.GetLabels()
 		}
+		matching := matchingPolicies(*resource, nsLabels, backgroundPolicies...)
+		if err := c.throttle(ctx, namespace, matching...); err != nil {
+			return err
+		}
 		var responses []*response.EngineResponse
-		for _, result := range scanner.ScanResource(ctx, *resource, nsLabels, backgroundPolicies...) {
+		dependencies := map[string][]string{}
+		for policy, result := range scanner.ScanResource(ctx, *resource, nsLabels, backgroundPolicies...) {
 			if result.Error != nil {
 				logger.Error(result.Error, "failed to apply policy")
 			} else {
 				responses = append(responses, result.EngineResponse)
+				dependencies[reportutils.PolicyLabel(policy)] = result.ConfigMaps
 			}
 		}
+		for _, policy := range matching {
+			c.recordScan(ctx, policy, namespace, metrics.BackgroundScanScanned)
+		}
 		reportutils.SetResponses(report, responses...)
-		if utils.ReportsAreIdentical(before, report) {
+		reportutils.SetConfigMapDependencies(report, dependencies)
+		if utils.ReportsAreIdentical(before, report) && reflect.DeepEqual(before.GetAnnotations(), report.GetAnnotations()) {
 			return nil
 		}
 		_, err = reportutils.UpdateReport(ctx, report, c.kyvernoClient)
@@ -279,8 +398,8 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		}
 		var toCreate []kyvernov1.PolicyInterface
 		for label, policy := range expected {
-			// if the background policy changed, we need to recreate entries
-			if metaLabels[label] != policy.GetResourceVersion() {
+			// if the background policy changed or read a config map that changed, we need to recreate entries
+			if metaLabels[label] != policy.GetResourceVersion() || stale.Has(label) {
 				if name, err := reportutils.PolicyNameFromLabel(namespace, label); err != nil {
 					return err
 				} else {
@@ -289,7 +408,9 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 				toCreate = append(toCreate, policy)
 			}
 		}
-		if len(toDelete) == 0 && len(toCreate) == 0 {
+		// a requested scan must be acknowledged even if there's nothing to rescan
+		scanRequested := controllerutils.HasLabel(meta, reportutils.LabelScanRequested)
+		if len(toDelete) == 0 && len(toCreate) == 0 && !scanRequested {
 			return nil
 		}
 		before, err := c.getReport(ctx, meta.GetNamespace(), meta.GetName())
@@ -298,12 +419,17 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		}
 		report := reportutils.DeepCopy(before)
 		var ruleResults []policyreportv1alpha2.PolicyReportResult
+		dependencies := reportutils.GetConfigMapDependencies(report)
 		// deletions
+		for _, label := range toDelete {
+			delete(dependencies, label)
+		}
 		reportLabels := report.GetLabels()
 		if reportLabels != nil {
 			for _, label := range toDelete {
 				delete(reportLabels, label)
 			}
+			delete(reportLabels, reportutils.LabelScanRequested)
 		}
 		for _, result := range report.GetResults() {
 			if _, ok := toDelete[result.Policy]; !ok {
@@ -326,17 +452,26 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 				}
 				nsLabels = ns.GetLabels()
 			}
-			for _, result := range scanner.ScanResource(ctx, *resource, nsLabels, toCreate...) {
+			matching := matchingPolicies(*resource, nsLabels, toCreate...)
+			if err := c.throttle(ctx, namespace, matching...); err != nil {
+				return err
+			}
+			for policy, result := range scanner.ScanResource(ctx, *resource, nsLabels, toCreate...) {
 				if result.Error != nil {
 					return result.Error
 				} else {
 					reportutils.SetPolicyLabel(report, result.EngineResponse.Policy)
 					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(result.EngineResponse)...)
+					dependencies[reportutils.PolicyLabel(policy)] = result.ConfigMaps
 				}
 			}
+			for _, policy := range matching {
+				c.recordScan(ctx, policy, namespace, metrics.BackgroundScanScanned)
+			}
 		}
 		reportutils.SetResults(report, ruleResults...)
-		if utils.ReportsAreIdentical(before, report) {
+		reportutils.SetConfigMapDependencies(report, dependencies)
+		if utils.ReportsAreIdentical(before, report) && reflect.DeepEqual(before.GetAnnotations(), report.GetAnnotations()) {
 			return nil
 		}
 		_, err = reportutils.UpdateReport(ctx, report, c.kyvernoClient)
@@ -399,5 +534,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		}
 		return err
 	}
-	return c.updateReport(ctx, report, gvk, resource)
+	stale := c.takeStale(key)
+	if err := c.updateReport(ctx, report, gvk, resource, stale); err != nil {
+		if stale.Len() > 0 {
+			c.markStale(key, stale.List()...)
+		}
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,215 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newReport(namespace, name string, dependencies map[string][]string) *kyvernov1alpha2.BackgroundScanReport {
+	report := &kyvernov1alpha2.BackgroundScanReport{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	reportutils.SetConfigMapDependencies(report, dependencies)
+	return report
+}
+
+func Test_EnqueueConfigMapDependents(t *testing.T) {
+	indexers := cache.Indexers{configMapIndex: configMapIndexFunc}
+	c := controller{
+		bgscanrIndexer:  cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers),
+		cbgscanrIndexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers),
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		stale:           map[string]sets.String{},
+	}
+	assert.NilError(t, c.bgscanrIndexer.Add(newReport("test", "a", map[string][]string{
+		"cpol.kyverno.io/pol1": {"default/cm"},
+		"cpol.kyverno.io/pol2": {"default/other"},
+	})))
+	assert.NilError(t, c.bgscanrIndexer.Add(newReport("test", "b", map[string][]string{
+		"pol.kyverno.io/pol3": {"default/other"},
+	})))
+	assert.NilError(t, c.cbgscanrIndexer.Add(newReport("", "c", map[string][]string{
+		"cpol.kyverno.io/pol1": {"default/cm"},
+	})))
+	c.deleteConfigMap(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}})
+	assert.Equal(t, c.queue.Len(), 2)
+	assert.DeepEqual(t, c.takeStale("test/a").List(), []string{"cpol.kyverno.io/pol1"})
+	assert.DeepEqual(t, c.takeStale("c").List(), []string{"cpol.kyverno.io/pol1"})
+	assert.Equal(t, c.takeStale("test/b").Len(), 0)
+	// unchanged config maps are ignored
+	cm := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", ResourceVersion: "1"}}
+	c.updateConfigMap(cm, cm)
+	assert.Equal(t, c.queue.Len(), 2)
+}
+
+func Test_EnqueueUnlabelledConfigMapDependents(t *testing.T) {
+	indexers := cache.Indexers{configMapIndex: configMapIndexFunc}
+	c := controller{
+		bgscanrIndexer:  cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers),
+		cbgscanrIndexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers),
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		stale:           map[string]sets.String{},
+	}
+	assert.NilError(t, c.bgscanrIndexer.Add(newReport("test", "a", map[string][]string{
+		"cpol.kyverno.io/pol1": {"default/cm"},
+	})))
+	// the config map has no cache label, it is only known to the metadata informer
+	cm := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm", ResourceVersion: "1"},
+	}
+	scheme := metadatafake.NewTestScheme()
+	assert.NilError(t, metav1.AddMetaToScheme(scheme))
+	client := metadatafake.NewSimpleMetadataClient(scheme, cm)
+	factory := metadatainformers.NewSharedInformerFactory(client, 0)
+	informer := factory.ForResource(corev1.SchemeGroupVersion.WithResource("configmaps")).Informer()
+	controllerutils.AddEventHandlersT(informer, c.addConfigMap, c.updateConfigMap, c.deleteConfigMap)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	assert.Equal(t, c.queue.Len(), 0)
+	_, err := client.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace("default").Patch(
+		ctx, "cm", types.MergePatchType, []byte(`{"metadata":{"resourceVersion":"2"}}`), metav1.PatchOptions{},
+	)
+	assert.NilError(t, err)
+	assert.NilError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return c.queue.Len() == 1, nil
+	}))
+	assert.DeepEqual(t, c.takeStale("test/a").List(), []string{"cpol.kyverno.io/pol1"})
+}
+
+func newPolicy(name string, interval time.Duration, priority int32) kyvernov1.PolicyInterface {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kyvernov1.Spec{
+			BackgroundScanSchedule: &kyvernov1.BackgroundScanSchedule{
+				Interval: &metav1.Duration{Duration: interval},
+				Priority: priority,
+			},
+		},
+	}
+}
+
+func Test_DuePolicies(t *testing.T) {
+	c := controller{lastScheduled: map[string]time.Time{}}
+	policies := []kyvernov1.PolicyInterface{
+		newPolicy("low", time.Hour, 0),
+		newPolicy("high", time.Hour, 10),
+		newPolicy("later", 2*time.Hour, 20),
+		newPolicy("disabled", 0, 30),
+	}
+	now := time.Now()
+	// intervals start when policies are first seen
+	assert.Equal(t, len(c.duePolicies(now, policies...)), 0)
+	due := c.duePolicies(now.Add(time.Hour), policies...)
+	assert.Equal(t, len(due), 2)
+	assert.Equal(t, due[0].GetName(), "high")
+	assert.Equal(t, due[1].GetName(), "low")
+	due = c.duePolicies(now.Add(2*time.Hour), policies...)
+	assert.Equal(t, len(due), 3)
+	assert.Equal(t, due[0].GetName(), "later")
+	// deleted policies are forgotten
+	c.duePolicies(now.Add(2*time.Hour), policies[0])
+	assert.Equal(t, len(c.lastScheduled), 1)
+}
+
+func Test_DuePoliciesSchedule(t *testing.T) {
+	c := controller{lastScheduled: map[string]time.Time{}}
+	policy := newPolicy("cron", 0, 0)
+	policy.GetSpec().BackgroundScanSchedule.Interval = nil
+	policy.GetSpec().BackgroundScanSchedule.Schedule = "0 * * * *"
+	now := time.Date(2022, 12, 1, 10, 15, 0, 0, time.UTC)
+	assert.Equal(t, len(c.duePolicies(now, policy)), 0)
+	assert.Equal(t, len(c.duePolicies(now.Add(30*time.Minute), policy)), 0)
+	assert.Equal(t, len(c.duePolicies(now.Add(45*time.Minute), policy)), 1)
+	assert.Equal(t, len(c.duePolicies(now.Add(50*time.Minute), policy)), 0)
+}
+
+func Test_DispatchScheduled(t *testing.T) {
+	resource := kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports").GroupResource()
+	bgscanrIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := controller{
+		bgscanrLister:  cache.NewGenericLister(bgscanrIndexer, resource),
+		cbgscanrLister: cache.NewGenericLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}), resource),
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		stale:          map[string]sets.String{},
+	}
+	high := newPolicy("high", time.Hour, 10)
+	low := newPolicy("low", time.Hour, 0)
+	report := newReport("test", "a", nil)
+	reportutils.SetPolicyLabel(report, high)
+	reportutils.SetPolicyLabel(report, low)
+	assert.NilError(t, bgscanrIndexer.Add(report))
+	// unrelated keys stay in the queue and must not hold back lower priority scans
+	c.queue.Add("test/other")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.dispatchScheduled(ctx, logr.Discard(), high, low)
+	}()
+	assert.NilError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return c.isPending(scheduledScan{label: reportutils.PolicyLabel(high), keys: []string{"test/a"}}), nil
+	}))
+	// the low priority scan waits for the high priority report to be picked up
+	time.Sleep(100 * time.Millisecond)
+	assert.Assert(t, !c.isPending(scheduledScan{label: reportutils.PolicyLabel(low), keys: []string{"test/a"}}))
+	assert.DeepEqual(t, c.takeStale("test/a").List(), []string{reportutils.PolicyLabel(high)})
+	<-done
+	assert.Assert(t, ctx.Err() == nil)
+	assert.DeepEqual(t, c.takeStale("test/a").List(), []string{reportutils.PolicyLabel(low)})
+}
+
+func Test_MatchingPolicies(t *testing.T) {
+	newRule := func(kind string) kyvernov1.Rule {
+		return kyvernov1.Rule{
+			Name: "rule",
+			MatchResources: kyvernov1.MatchResources{
+				ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{kind}},
+			},
+			Validation: kyvernov1.Validation{Message: "message", RawPattern: kyvernov1.ToJSON(map[string]interface{}{"metadata": map[string]interface{}{"name": "?*"}})},
+		}
+	}
+	configMaps := newPolicy("configmaps", 0, 0)
+	configMaps.GetSpec().SetRules([]kyvernov1.Rule{newRule("ConfigMap")})
+	secrets := newPolicy("secrets", 0, 0)
+	secrets.GetSpec().SetRules([]kyvernov1.Rule{newRule("Secret")})
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetNamespace("test")
+	resource.SetName("cm")
+	matching := matchingPolicies(resource, nil, configMaps, secrets)
+	assert.Equal(t, len(matching), 1)
+	assert.Equal(t, matching[0].GetName(), "configmaps")
+}
+
+func Test_GetLimiter(t *testing.T) {
+	c := controller{limiters: map[string]policyLimiter{}}
+	policy := newPolicy("pol", time.Hour, 0)
+	assert.Assert(t, c.getLimiter(policy) == nil)
+	limit := int32(5)
+	policy.GetSpec().BackgroundScanSchedule.MaxResourcesPerSecond = &limit
+	limiter := c.getLimiter(policy)
+	assert.Assert(t, limiter != nil)
+	assert.Equal(t, c.getLimiter(policy), limiter)
+	assert.Equal(t, limiter.Burst(), 5)
+}
@@ -0,0 +1,264 @@
+package background
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/robfig/cron"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+// schedulePeriod is how often policies are checked for periodic rescans
+const schedulePeriod = 30 * time.Second
+
+// policyLimiter throttles the scans of a policy according to its max resources per second
+type policyLimiter struct {
+	limit   int32
+	limiter *rate.Limiter
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	key, _ := cache.MetaNamespaceKeyFunc(policy)
+	return key
+}
+
+// sortByPriority sorts policies by decreasing background scan priority, then by name
+func sortByPriority(policies []kyvernov1.PolicyInterface) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		pi, pj := policies[i].GetSpec().GetBackgroundScanPriority(), policies[j].GetSpec().GetBackgroundScanPriority()
+		if pi != pj {
+			return pi > pj
+		}
+		return policyKey(policies[i]) < policyKey(policies[j])
+	})
+}
+
+// matchingPolicies returns the policies with at least one validate or verify images rule matching the resource,
+// other policies don't produce any result when scanned and are neither throttled nor recorded
+func matchingPolicies(resource unstructured.Unstructured, nsLabels map[string]string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	var matching []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy) {
+			if !rule.HasValidate() && !rule.HasVerifyImages() {
+				continue
+			}
+			if engine.MatchesResourceDescription(nil, resource, rule, kyvernov1beta1.RequestInfo{}, nil, nsLabels, policy.GetNamespace(), "") == nil {
+				matching = append(matching, policy)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+// throttle waits until the rate limits of the policies allow to scan a resource
+func (c *controller) throttle(ctx context.Context, resourceNamespace string, policies ...kyvernov1.PolicyInterface) error {
+	for _, policy := range policies {
+		limiter := c.getLimiter(policy)
+		if limiter == nil {
+			continue
+		}
+		if !limiter.Allow() {
+			c.recordScan(ctx, policy, resourceNamespace, metrics.BackgroundScanThrottled)
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getLimiter returns the rate limiter of a policy, nil if the policy scans are not throttled
+func (c *controller) getLimiter(policy kyvernov1.PolicyInterface) *rate.Limiter {
+	limit := policy.GetSpec().GetBackgroundScanMaxResourcesPerSecond()
+	key := policyKey(policy)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if limit <= 0 {
+		delete(c.limiters, key)
+		return nil
+	}
+	if l, ok := c.limiters[key]; ok && l.limit == limit {
+		return l.limiter
+	}
+	limiter := rate.NewLimiter(rate.Limit(limit), int(limit))
+	c.limiters[key] = policyLimiter{limit: limit, limiter: limiter}
+	return limiter
+}
+
+func (c *controller) recordScan(ctx context.Context, policy kyvernov1.PolicyInterface, resourceNamespace string, event metrics.BackgroundScanEvent) {
+	if c.metrics == nil {
+		return
+	}
+	policyType := metrics.Namespaced
+	policyNamespace := policy.GetNamespace()
+	if policyNamespace == "" {
+		policyType = metrics.Cluster
+		policyNamespace = "-"
+	}
+	if c.metrics.Config().CheckNamespace(policyNamespace) {
+		c.metrics.RecordBackgroundScan(ctx, policyType, policyNamespace, policy.GetName(), resourceNamespace, event)
+	}
+}
+
+// scheduleScans periodically enqueues the reports of the policies with a scan interval or schedule,
+// policies due at the same time are dispatched by decreasing priority
+func (c *controller) scheduleScans(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		policies, err := c.fetchClusterPolicies(logger)
+		if err != nil {
+			logger.Error(err, "failed to list cluster policies")
+			return
+		}
+		pols, err := c.fetchPolicies(logger, metav1.NamespaceAll)
+		if err != nil {
+			logger.Error(err, "failed to list policies")
+			return
+		}
+		policies = append(policies, pols...)
+		c.dispatchScheduled(ctx, logger, c.duePolicies(time.Now(), policies...)...)
+	}, schedulePeriod)
+}
+
+// scheduledScan contains the reports enqueued for a periodic scan of a policy
+type scheduledScan struct {
+	label string
+	keys  []string
+}
+
+// dispatchScheduled enqueues the reports of the due policies, the reports of a policy are only enqueued once
+// the reports of the policies with a higher priority have been picked up by workers, or after waiting for
+// a schedule period so that a busy queue doesn't hold lower priority scans back indefinitely
+func (c *controller) dispatchScheduled(ctx context.Context, logger logr.Logger, policies ...kyvernov1.PolicyInterface) {
+	var dispatched []scheduledScan
+	for i, policy := range policies {
+		if i > 0 && policy.GetSpec().GetBackgroundScanPriority() < policies[i-1].GetSpec().GetBackgroundScanPriority() {
+			waitCtx, cancel := context.WithTimeout(ctx, schedulePeriod)
+			err := wait.PollImmediateUntilWithContext(waitCtx, time.Second, func(context.Context) (bool, error) {
+				return !c.isPending(dispatched...), nil
+			})
+			cancel()
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logger.V(4).Info("higher priority scans still pending, dispatching lower priority scans", "policy", policyKey(policy))
+			}
+			dispatched = nil
+		}
+		keys, err := c.enqueueScheduled(ctx, policy)
+		if err != nil {
+			logger.Error(err, "failed to enqueue scheduled scan", "policy", policyKey(policy))
+		}
+		dispatched = append(dispatched, scheduledScan{label: reportutils.PolicyLabel(policy), keys: keys})
+	}
+}
+
+// isPending returns true if a worker didn't pick up one of the reports enqueued for a scheduled scan yet
+func (c *controller) isPending(scans ...scheduledScan) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, scan := range scans {
+		for _, key := range scan.keys {
+			if c.stale[key].Has(scan.label) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDue returns true if the scan interval or the next scheduled time of a policy elapsed since the last scan
+func isDue(spec *kyvernov1.Spec, last, now time.Time) bool {
+	if interval := spec.GetBackgroundScanInterval(); interval > 0 {
+		return now.Sub(last) >= interval
+	}
+	schedule, err := cron.ParseStandard(spec.GetBackgroundScanSchedule())
+	if err != nil {
+		return false
+	}
+	return !now.Before(schedule.Next(last))
+}
+
+// duePolicies returns the policies whose scan interval or schedule elapsed, sorted by priority,
+// the first time a policy is seen its interval or schedule starts
+func (c *controller) duePolicies(now time.Time, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	seen := map[string]bool{}
+	var due []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		spec := policy.GetSpec()
+		if !spec.BackgroundProcessingEnabled() || (spec.GetBackgroundScanInterval() <= 0 && spec.GetBackgroundScanSchedule() == "") {
+			continue
+		}
+		key := policyKey(policy)
+		seen[key] = true
+		last, ok := c.lastScheduled[key]
+		if !ok {
+			c.lastScheduled[key] = now
+		} else if isDue(spec, last, now) {
+			c.lastScheduled[key] = now
+			due = append(due, policy)
+		}
+	}
+	for key := range c.lastScheduled {
+		if !seen[key] {
+			delete(c.lastScheduled, key)
+		}
+	}
+	sortByPriority(due)
+	return due
+}
+
+// enqueueScheduled marks the policy as stale in the reports it applies to, enqueues them and returns their keys
+func (c *controller) enqueueScheduled(ctx context.Context, policy kyvernov1.PolicyInterface) ([]string, error) {
+	selector, err := reportutils.SelectorPolicyExists(policy)
+	if err != nil {
+		return nil, err
+	}
+	label := reportutils.PolicyLabel(policy)
+	var objs []runtime.Object
+	if policy.GetNamespace() == "" {
+		for _, lister := range []cache.GenericLister{c.bgscanrLister, c.cbgscanrLister} {
+			reports, err := lister.List(selector)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, reports...)
+		}
+	} else {
+		// namespaced policy labels don't contain the policy namespace
+		reports, err := c.bgscanrLister.ByNamespace(policy.GetNamespace()).List(selector)
+		if err != nil {
+			return nil, err
+		}
+		objs = reports
+	}
+	var keys []string
+	for _, obj := range objs {
+		meta := obj.(metav1.Object)
+		key, err := cache.MetaNamespaceKeyFunc(meta)
+		if err != nil {
+			return keys, err
+		}
+		c.markStale(key, label)
+		c.queue.Add(key)
+		c.recordScan(ctx, policy, meta.GetNamespace(), metrics.BackgroundScanScheduled)
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
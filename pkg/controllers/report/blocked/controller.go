@@ -0,0 +1,394 @@
+package blocked
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"golang.org/x/exp/slices"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "blocked-report-controller"
+	maxRetries     = 10
+	// ReportName is the name of the reports containing blocked admission requests
+	ReportName = "kyverno-blocked-requests"
+	// DefaultRetention is the default duration blocked requests are kept in reports
+	DefaultRetention = 24 * time.Hour
+	// DefaultMaxResults is the default maximum number of blocked requests kept per namespace
+	DefaultMaxResults = 1000
+	// DefaultChunkSize is the default maximum number of blocked requests stored in a single report
+	DefaultChunkSize = 1000
+	// maxChunkBytes bounds the encoded size of the requests stored in a single report, well below
+	// the size limit of objects stored in etcd
+	maxChunkBytes = 512 * 1024
+	// pruneInterval is how often existing reports are checked for expired requests
+	pruneInterval = time.Hour
+)
+
+// Recorder records blocked admission requests
+type Recorder interface {
+	controllers.Controller
+	// Record records the rules that caused an admission request to be blocked
+	Record(request *admissionv1.AdmissionRequest, failurePolicy kyvernov1.FailurePolicyType, engineResponses ...*response.EngineResponse)
+}
+
+// controller appends blocked admission requests to the blocked request reports of their namespace,
+// requests older than the retention or above the max number of requests are dropped and the remaining
+// ones are split in several reports when they don't fit in a single one
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// config
+	retention  time.Duration
+	maxResults int
+	chunkSize  int
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	lock    sync.Mutex
+	pending map[string][]kyvernov1alpha2.BlockedRequest
+}
+
+func NewController(
+	client versioned.Interface,
+	retention time.Duration,
+	maxResults int,
+	chunkSize int,
+) Recorder {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	if maxResults <= 0 {
+		maxResults = DefaultMaxResults
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &controller{
+		client:     client,
+		retention:  retention,
+		maxResults: maxResults,
+		chunkSize:  chunkSize,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		pending:    map[string][]kyvernov1alpha2.BlockedRequest{},
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.enqueueReports)
+}
+
+// enqueueReports periodically enqueues existing reports so that expired requests are pruned
+// even when no request is blocked
+func (c *controller) enqueueReports(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		reports, err := c.client.KyvernoV1alpha2().BlockedRequestReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logger.Error(err, "failed to list blocked request reports")
+		} else {
+			namespaces := sets.NewString()
+			for _, report := range reports.Items {
+				namespaces.Insert(report.GetNamespace())
+			}
+			for _, namespace := range namespaces.List() {
+				c.queue.Add(namespace)
+			}
+		}
+		clusterReports, err := c.client.KyvernoV1alpha2().ClusterBlockedRequestReports().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			logger.Error(err, "failed to list cluster blocked request reports")
+		} else if len(clusterReports.Items) > 0 {
+			c.queue.Add("")
+		}
+	}, pruneInterval)
+}
+
+func (c *controller) Record(request *admissionv1.AdmissionRequest, failurePolicy kyvernov1.FailurePolicyType, engineResponses ...*response.EngineResponse) {
+	if request.DryRun != nil && *request.DryRun {
+		return
+	}
+	requests := buildRequests(request, failurePolicy, metav1.Now(), engineResponses...)
+	if len(requests) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pending[request.Namespace] = append(c.pending[request.Namespace], requests...)
+	c.queue.Add(request.Namespace)
+}
+
+func buildRequests(request *admissionv1.AdmissionRequest, failurePolicy kyvernov1.FailurePolicyType, now metav1.Time, engineResponses ...*response.EngineResponse) []kyvernov1alpha2.BlockedRequest {
+	resource := corev1.ObjectReference{
+		APIVersion: schema.GroupVersion{Group: request.Kind.Group, Version: request.Kind.Version}.String(),
+		Kind:       request.Kind.Kind,
+		Namespace:  request.Namespace,
+		Name:       request.Name,
+	}
+	var requests []kyvernov1alpha2.BlockedRequest
+	for _, er := range engineResponses {
+		if !engineutils.BlockRequest(er, failurePolicy) {
+			continue
+		}
+		policy, err := cache.MetaNamespaceKeyFunc(er.Policy)
+		if err != nil {
+			logger.Error(err, "failed to compute policy key")
+			continue
+		}
+		for _, rule := range er.PolicyResponse.Rules {
+			var result policyreportv1alpha2.PolicyResult
+			switch rule.Status {
+			case response.RuleStatusFail:
+				result = policyreportv1alpha2.StatusFail
+			case response.RuleStatusError:
+				result = policyreportv1alpha2.StatusError
+			default:
+				continue
+			}
+			requests = append(requests, kyvernov1alpha2.BlockedRequest{
+				Policy:    policy,
+				Rule:      rule.Name,
+				Result:    result,
+				Message:   rule.Message,
+				Resource:  resource,
+				Operation: string(request.Operation),
+				UID:       request.UID,
+				Username:  request.UserInfo.Username,
+				Groups:    slices.Clone(request.UserInfo.Groups),
+				Timestamp: now,
+			})
+		}
+	}
+	return requests
+}
+
+func (c *controller) takePending(namespace string) []kyvernov1alpha2.BlockedRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	requests := c.pending[namespace]
+	delete(c.pending, namespace)
+	return requests
+}
+
+// restorePending puts back requests that could not be stored, before the ones recorded in the meantime
+func (c *controller) restorePending(namespace string, requests []kyvernov1alpha2.BlockedRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pending[namespace] = append(requests, c.pending[namespace]...)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	requests := c.takePending(key)
+	var err error
+	if key == "" {
+		client := c.client.KyvernoV1alpha2().ClusterBlockedRequestReports()
+		list := func(ctx context.Context) ([]*kyvernov1alpha2.ClusterBlockedRequestReport, error) {
+			reports, err := client.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			var items []*kyvernov1alpha2.ClusterBlockedRequestReport
+			for i := range reports.Items {
+				items = append(items, &reports.Items[i])
+			}
+			return items, nil
+		}
+		err = reconcileReports[*kyvernov1alpha2.ClusterBlockedRequestReport](ctx, client, list, newClusterReport, c.prune, c.split, requests...)
+	} else {
+		client := c.client.KyvernoV1alpha2().BlockedRequestReports(key)
+		list := func(ctx context.Context) ([]*kyvernov1alpha2.BlockedRequestReport, error) {
+			reports, err := client.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			var items []*kyvernov1alpha2.BlockedRequestReport
+			for i := range reports.Items {
+				items = append(items, &reports.Items[i])
+			}
+			return items, nil
+		}
+		err = reconcileReports[*kyvernov1alpha2.BlockedRequestReport](ctx, client, list, func(name string) *kyvernov1alpha2.BlockedRequestReport {
+			return newReport(key, name)
+		}, c.prune, c.split, requests...)
+	}
+	if err != nil {
+		c.restorePending(key, requests)
+		return err
+	}
+	logger.V(4).Info("recorded blocked requests", "count", len(requests))
+	return nil
+}
+
+func newReport(namespace, name string) *kyvernov1alpha2.BlockedRequestReport {
+	report := &kyvernov1alpha2.BlockedRequestReport{}
+	report.SetName(name)
+	report.SetNamespace(namespace)
+	controllerutils.SetManagedByKyvernoLabel(report)
+	return report
+}
+
+func newClusterReport(name string) *kyvernov1alpha2.ClusterBlockedRequestReport {
+	report := &kyvernov1alpha2.ClusterBlockedRequestReport{}
+	report.SetName(name)
+	controllerutils.SetManagedByKyvernoLabel(report)
+	return report
+}
+
+// reportName returns the name of the report storing the chunk of requests at the given index
+func reportName(index int) string {
+	if index == 0 {
+		return ReportName
+	}
+	return fmt.Sprintf("%s-%d", ReportName, index)
+}
+
+// isBlockedRequestReport returns true if the report is one of the chunks of blocked requests
+func isBlockedRequestReport(report metav1.Object) bool {
+	if !controllerutils.IsManagedByKyverno(report) {
+		return false
+	}
+	name := report.GetName()
+	return name == ReportName || strings.HasPrefix(name, ReportName+"-")
+}
+
+// blockedRequestReport is implemented by both the namespaced and the cluster blocked request reports
+type blockedRequestReport interface {
+	metav1.Object
+	runtime.Object
+	GetRequests() []kyvernov1alpha2.BlockedRequest
+	SetRequests([]kyvernov1alpha2.BlockedRequest)
+}
+
+type reportClient[T blockedRequestReport] interface {
+	Create(context.Context, T, metav1.CreateOptions) (T, error)
+	Update(context.Context, T, metav1.UpdateOptions) (T, error)
+	Delete(context.Context, string, metav1.DeleteOptions) error
+}
+
+// reconcileReports merges the requests with the ones already stored, prunes expired ones and
+// spreads the remaining requests over as many reports as needed, extra reports are deleted
+func reconcileReports[T blockedRequestReport](
+	ctx context.Context,
+	client reportClient[T],
+	list func(context.Context) ([]T, error),
+	newReport func(string) T,
+	prune func([]kyvernov1alpha2.BlockedRequest, time.Time) []kyvernov1alpha2.BlockedRequest,
+	split func([]kyvernov1alpha2.BlockedRequest) [][]kyvernov1alpha2.BlockedRequest,
+	requests ...kyvernov1alpha2.BlockedRequest,
+) error {
+	reports, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	actual := map[string]T{}
+	var all []kyvernov1alpha2.BlockedRequest
+	for _, report := range reports {
+		if isBlockedRequestReport(report) {
+			actual[report.GetName()] = report
+			all = append(all, report.GetRequests()...)
+		}
+	}
+	if len(actual) == 0 && len(requests) == 0 {
+		return nil
+	}
+	all = append(all, requests...)
+	slices.SortStableFunc(all, func(a, b kyvernov1alpha2.BlockedRequest) bool {
+		return a.Timestamp.Before(&b.Timestamp)
+	})
+	for i, chunk := range split(prune(all, time.Now())) {
+		name := reportName(i)
+		before, exists := actual[name]
+		delete(actual, name)
+		if !exists {
+			report := newReport(name)
+			report.SetRequests(chunk)
+			if _, err := client.Create(ctx, report, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+			continue
+		}
+		after := before.DeepCopyObject().(T)
+		after.SetRequests(chunk)
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+		if _, err := client.Update(ctx, after, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	for name := range actual {
+		if err := client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune drops the requests older than the retention and keeps at most maxResults of the most recent ones
+func (c *controller) prune(requests []kyvernov1alpha2.BlockedRequest, now time.Time) []kyvernov1alpha2.BlockedRequest {
+	oldest := now.Add(-c.retention)
+	var kept []kyvernov1alpha2.BlockedRequest
+	for _, request := range requests {
+		if !request.Timestamp.Time.Before(oldest) {
+			kept = append(kept, request)
+		}
+	}
+	if len(kept) > c.maxResults {
+		slices.SortStableFunc(kept, func(a, b kyvernov1alpha2.BlockedRequest) bool {
+			return a.Timestamp.Before(&b.Timestamp)
+		})
+		kept = kept[len(kept)-c.maxResults:]
+	}
+	return kept
+}
+
+// split splits the requests in chunks of at most chunkSize requests and maxChunkBytes of encoded requests
+func (c *controller) split(requests []kyvernov1alpha2.BlockedRequest) [][]kyvernov1alpha2.BlockedRequest {
+	var chunks [][]kyvernov1alpha2.BlockedRequest
+	var chunk []kyvernov1alpha2.BlockedRequest
+	var size int
+	for _, request := range requests {
+		data, err := json.Marshal(request)
+		if err != nil {
+			logger.Error(err, "failed to encode blocked request")
+			continue
+		}
+		if len(chunk) > 0 && (len(chunk) >= c.chunkSize || size+len(data) > maxChunkBytes) {
+			chunks = append(chunks, chunk)
+			chunk, size = nil, 0
+		}
+		chunk = append(chunk, request)
+		size += len(data)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
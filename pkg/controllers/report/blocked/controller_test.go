@@ -0,0 +1,173 @@
+package blocked
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEngineResponse(action kyvernov1.ValidationFailureAction, status response.RuleStatus) *response.EngineResponse {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-privileged"}}
+	return &response.EngineResponse{
+		Policy: policy,
+		PolicyResponse: response.PolicyResponse{
+			ValidationFailureAction: action,
+			Rules: []response.RuleResponse{
+				{Name: "privileged", Status: status, Message: "privileged containers are not allowed"},
+				{Name: "other", Status: response.RuleStatusPass},
+			},
+		},
+	}
+}
+
+func newRequest() *admissionv1.AdmissionRequest {
+	return &admissionv1.AdmissionRequest{
+		UID:       "request-uid",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Namespace: "test",
+		Name:      "nginx",
+		Operation: admissionv1.Create,
+		UserInfo: authenticationv1.UserInfo{
+			Username: "alice",
+			Groups:   []string{"dev", "system:authenticated"},
+		},
+	}
+}
+
+func Test_BuildRequests(t *testing.T) {
+	now := metav1.Now()
+	requests := buildRequests(newRequest(), kyvernov1.Ignore, now,
+		newEngineResponse(kyvernov1.ValidationFailureAction("Enforce"), response.RuleStatusFail),
+		newEngineResponse(kyvernov1.ValidationFailureAction("Audit"), response.RuleStatusFail),
+	)
+	assert.Equal(t, len(requests), 1)
+	assert.DeepEqual(t, requests[0], kyvernov1alpha2.BlockedRequest{
+		Policy:    "disallow-privileged",
+		Rule:      "privileged",
+		Result:    policyreportv1alpha2.StatusFail,
+		Message:   "privileged containers are not allowed",
+		Resource:  corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "test", Name: "nginx"},
+		Operation: "CREATE",
+		UID:       "request-uid",
+		Username:  "alice",
+		Groups:    []string{"dev", "system:authenticated"},
+		Timestamp: now,
+	})
+}
+
+func Test_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	c := NewController(client, time.Hour, 2, 0).(*controller)
+	for i := 0; i < 3; i++ {
+		c.Record(newRequest(), kyvernov1.Ignore, newEngineResponse(kyvernov1.ValidationFailureAction("Enforce"), response.RuleStatusFail))
+	}
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	report, err := client.KyvernoV1alpha2().BlockedRequestReports("test").Get(ctx, ReportName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, controllerutils.IsManagedByKyverno(report))
+	assert.Equal(t, len(report.Spec.Requests), 2)
+	// blocked requests are not published as policy report results
+	polrs, err := client.Wgpolicyk8sV1alpha2().PolicyReports("test").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(polrs.Items), 0)
+	// expired requests are pruned, the report is deleted when empty
+	report.Spec.Requests[0].Timestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	report.Spec.Requests[1].Timestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	_, err = client.KyvernoV1alpha2().BlockedRequestReports("test").Update(ctx, report, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	_, err = client.KyvernoV1alpha2().BlockedRequestReports("test").Get(ctx, ReportName, metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func Test_ReconcileCluster(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	c := NewController(client, time.Hour, 10, 0).(*controller)
+	request := newRequest()
+	request.Namespace = ""
+	request.Kind = metav1.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	c.Record(request, kyvernov1.Ignore, newEngineResponse(kyvernov1.ValidationFailureAction("Enforce"), response.RuleStatusFail))
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "", "", ""))
+	report, err := client.KyvernoV1alpha2().ClusterBlockedRequestReports().Get(ctx, ReportName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.Spec.Requests), 1)
+	assert.Equal(t, report.Spec.Requests[0].Resource.Kind, "Namespace")
+}
+
+func Test_ReconcileChunks(t *testing.T) {
+	ctx := context.TODO()
+	client := fake.NewSimpleClientset()
+	c := NewController(client, time.Hour, 10, 2).(*controller)
+	for i := 0; i < 5; i++ {
+		c.Record(newRequest(), kyvernov1.Ignore, newEngineResponse(kyvernov1.ValidationFailureAction("Enforce"), response.RuleStatusFail))
+	}
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	reports, err := client.KyvernoV1alpha2().BlockedRequestReports("test").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports.Items), 3)
+	for _, name := range []string{ReportName, ReportName + "-1", ReportName + "-2"} {
+		_, err := client.KyvernoV1alpha2().BlockedRequestReports("test").Get(ctx, name, metav1.GetOptions{})
+		assert.NilError(t, err)
+	}
+	// extra chunks are deleted when requests expire
+	report, err := client.KyvernoV1alpha2().BlockedRequestReports("test").Get(ctx, ReportName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	for i := range report.Spec.Requests {
+		report.Spec.Requests[i].Timestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	}
+	_, err = client.KyvernoV1alpha2().BlockedRequestReports("test").Update(ctx, report, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	reports, err = client.KyvernoV1alpha2().BlockedRequestReports("test").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports.Items), 2)
+	_, err = client.KyvernoV1alpha2().BlockedRequestReports("test").Get(ctx, ReportName+"-2", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func Test_Split(t *testing.T) {
+	c := &controller{chunkSize: 10}
+	small := kyvernov1alpha2.BlockedRequest{Policy: "small"}
+	large := kyvernov1alpha2.BlockedRequest{Policy: "large", Message: strings.Repeat("x", maxChunkBytes/2)}
+	chunks := c.split([]kyvernov1alpha2.BlockedRequest{small, large, large, small})
+	// requests are split by encoded size before reaching the chunk size
+	assert.Equal(t, len(chunks), 2)
+	assert.Equal(t, len(chunks[0]), 2)
+	assert.Equal(t, len(chunks[1]), 2)
+	c.chunkSize = 1
+	assert.Equal(t, len(c.split([]kyvernov1alpha2.BlockedRequest{small, small, small})), 3)
+}
+
+func Test_Prune(t *testing.T) {
+	c := &controller{retention: time.Hour, maxResults: 2}
+	now := time.Now()
+	at := func(policy string, d time.Duration) kyvernov1alpha2.BlockedRequest {
+		return kyvernov1alpha2.BlockedRequest{Policy: policy, Timestamp: metav1.NewTime(now.Add(-d))}
+	}
+	requests := c.prune([]kyvernov1alpha2.BlockedRequest{
+		at("a", 2*time.Hour),
+		at("b", 10*time.Minute),
+		at("c", 30*time.Minute),
+		at("d", time.Minute),
+	}, now)
+	assert.Equal(t, len(requests), 2)
+	assert.Equal(t, requests[0].Policy, "b")
+	assert.Equal(t, requests[1].Policy, "d")
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const checkpointKey = "checkpoint.json.gz"
+
+// CheckpointEntry records the last result delivered for a policy, rule and resource
+type CheckpointEntry struct {
+	Hash     string                  `json:"hash"`
+	Policy   string                  `json:"policy"`
+	Rule     string                  `json:"rule,omitempty"`
+	Resource *corev1.ObjectReference `json:"resource,omitempty"`
+}
+
+// Checkpoint contains the results delivered for a partition, indexed by result key
+type Checkpoint map[string]CheckpointEntry
+
+func (c Checkpoint) clone() Checkpoint {
+	out := make(Checkpoint, len(c))
+	for k, v := range c {
+		out[k] = v
+	}
+	return out
+}
+
+// CheckpointStore persists the delivered results so that they are not sent again after a restart
+type CheckpointStore interface {
+	Load(ctx context.Context, partition Partition) (Checkpoint, error)
+	Save(ctx context.Context, partition Partition, checkpoint Checkpoint) error
+}
+
+type configMapStore struct {
+	client corev1client.ConfigMapInterface
+}
+
+// NewConfigMapStore returns a CheckpointStore saving checkpoints in config maps, one per partition,
+// the config map of a partition is deleted when no result is left
+func NewConfigMapStore(client corev1client.ConfigMapInterface) CheckpointStore {
+	return &configMapStore{
+		client: client,
+	}
+}
+
+func configMapName(partition Partition) string {
+	name := "kyverno-report-export-cluster"
+	if partition.Namespace != "" {
+		name = "kyverno-report-export-ns-" + partition.Namespace
+	}
+	if partition.Policy != "" {
+		// policy labels are not valid in names and can be too long, they are hashed instead
+		hash := sha256.Sum256([]byte(partition.Policy))
+		name += "-" + hex.EncodeToString(hash[:8])
+	}
+	return name
+}
+
+func (s *configMapStore) Load(ctx context.Context, partition Partition) (Checkpoint, error) {
+	cm, err := s.client.Get(ctx, configMapName(partition), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return Checkpoint{}, nil
+		}
+		return nil, err
+	}
+	data, ok := cm.BinaryData[checkpointKey]
+	if !ok {
+		return Checkpoint{}, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := Checkpoint{}
+	if err := json.Unmarshal(raw, &checkpoint); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+func (s *configMapStore) Save(ctx context.Context, partition Partition, checkpoint Checkpoint) error {
+	name := configMapName(partition)
+	if len(checkpoint) == 0 {
+		if err := s.client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(raw); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	cm, err := s.client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kyvernov1.LabelAppManagedBy: kyvernov1.ValueKyvernoApp,
+				},
+			},
+			BinaryData: map[string][]byte{
+				checkpointKey: buffer.Bytes(),
+			},
+		}
+		_, err := s.client.Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+	cm = cm.DeepCopy()
+	if cm.BinaryData == nil {
+		cm.BinaryData = map[string][]byte{}
+	}
+	cm.BinaryData[checkpointKey] = buffer.Bytes()
+	_, err = s.client.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsBatchType   = "application/cloudevents-batch+json"
+	// EventTypeNew is the type of events sent for results that were not reported before
+	EventTypeNew = "io.kyverno.report.result.new"
+	// EventTypeChanged is the type of events sent for results that changed since they were reported
+	EventTypeChanged = "io.kyverno.report.result.changed"
+	// EventTypeResolved is the type of events sent for results that are not reported anymore
+	EventTypeResolved = "io.kyverno.report.result.resolved"
+)
+
+// CloudEvent is a CloudEvents 1.0 event in structured JSON format
+type CloudEvent struct {
+	SpecVersion     string                                  `json:"specversion"`
+	ID              string                                  `json:"id"`
+	Source          string                                  `json:"source"`
+	Type            string                                  `json:"type"`
+	Subject         string                                  `json:"subject,omitempty"`
+	Time            time.Time                               `json:"time"`
+	DataContentType string                                  `json:"datacontenttype"`
+	Data            policyreportv1alpha2.PolicyReportResult `json:"data"`
+}
+
+func eventSource(namespace string) string {
+	if namespace == "" {
+		return "kyverno.io/reports/cluster"
+	}
+	return "kyverno.io/reports/namespaces/" + namespace
+}
+
+func newCloudEvent(eventType, namespace, key, hash string, timestamp time.Time, result policyreportv1alpha2.PolicyReportResult) CloudEvent {
+	// the id is deterministic so that receivers can deduplicate events sent again after a failure
+	id := sha256.Sum256([]byte(eventType + "/" + namespace + "/" + key + "/" + hash))
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              hex.EncodeToString(id[:]),
+		Source:          eventSource(namespace),
+		Type:            eventType,
+		Subject:         key,
+		Time:            timestamp.UTC(),
+		DataContentType: "application/json",
+		Data:            result,
+	}
+}
+
+// statusError is returned when the endpoint responds with an unexpected status code
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("endpoint returned status %d: %s", e.code, e.body)
+}
+
+// isRetriable returns true for network errors, throttling and server errors
+func isRetriable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= http.StatusInternalServerError
+	}
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+type sender struct {
+	client   *http.Client
+	endpoint string
+	backoff  wait.Backoff
+}
+
+// send posts a batch of events to the endpoint, retrying with backoff on transient errors
+func (s *sender) send(ctx context.Context, events []CloudEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return retry.OnError(s.backoff, isRetriable, func() error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", cloudEventsBatchType)
+		response, err := s.client.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode >= 300 {
+			message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+			return &statusError{code: response.StatusCode, body: string(message)}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,302 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-export-controller"
+	maxRetries     = 10
+	// DefaultBatchSize is the default maximum number of events sent in a single request
+	DefaultBatchSize = 100
+	requestTimeout   = 30 * time.Second
+)
+
+// Partition identifies a set of results exported together
+type Partition struct {
+	// Namespace of the results, empty for cluster wide results
+	Namespace string
+	// Policy is the report label of the policy of the results when reports are partitioned by policy,
+	// empty when the partition contains the results of all policies
+	Policy string
+}
+
+func (p Partition) key() string {
+	if p.Policy == "" {
+		return p.Namespace
+	}
+	return p.Namespace + "/" + p.Policy
+}
+
+// Exporter receives the aggregated results of a partition and sends the differences to an endpoint
+type Exporter interface {
+	controllers.Controller
+	// Export records the current results of a partition
+	Export(partition Partition, results []policyreportv1alpha2.PolicyReportResult)
+}
+
+type snapshot struct {
+	partition Partition
+	results   []policyreportv1alpha2.PolicyReportResult
+}
+
+type controller struct {
+	sender    sender
+	store     CheckpointStore
+	batchSize int
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	lock sync.Mutex
+	// snapshots contains the latest results not exported yet, per partition key
+	snapshots map[string]snapshot
+	// checkpoints caches the checkpoints loaded from the store, per partition key
+	checkpoints map[string]Checkpoint
+}
+
+// NewController returns an exporter sending new, changed and resolved results as batches of CloudEvents to the endpoint
+func NewController(
+	endpoint string,
+	batchSize int,
+	store CheckpointStore,
+) Exporter {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &controller{
+		sender: sender{
+			client:   &http.Client{Timeout: requestTimeout},
+			endpoint: endpoint,
+			backoff: wait.Backoff{
+				Steps:    5,
+				Duration: time.Second,
+				Factor:   2.0,
+				Jitter:   0.1,
+			},
+		},
+		store:       store,
+		batchSize:   batchSize,
+		queue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		snapshots:   map[string]snapshot{},
+		checkpoints: map[string]Checkpoint{},
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) Export(partition Partition, results []policyreportv1alpha2.PolicyReportResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := partition.key()
+	c.snapshots[key] = snapshot{partition: partition, results: results}
+	// partition keys don't have the namespace/name format
+	c.queue.Add(cache.ExplicitKey(key))
+}
+
+// takeSnapshot removes and returns the latest results of a partition
+func (c *controller) takeSnapshot(key string) (snapshot, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	snapshot, ok := c.snapshots[key]
+	delete(c.snapshots, key)
+	return snapshot, ok
+}
+
+// restoreSnapshot puts back results that could not be exported, unless newer results were recorded
+func (c *controller) restoreSnapshot(snapshot snapshot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.snapshots[snapshot.partition.key()]; !ok {
+		c.snapshots[snapshot.partition.key()] = snapshot
+	}
+}
+
+func (c *controller) loadCheckpoint(ctx context.Context, partition Partition) (Checkpoint, error) {
+	c.lock.Lock()
+	checkpoint, ok := c.checkpoints[partition.key()]
+	c.lock.Unlock()
+	if ok {
+		return checkpoint, nil
+	}
+	checkpoint, err := c.store.Load(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checkpoints[partition.key()] = checkpoint
+	return checkpoint, nil
+}
+
+func (c *controller) saveCheckpoint(ctx context.Context, partition Partition, checkpoint Checkpoint) error {
+	if err := c.store.Save(ctx, partition, checkpoint); err != nil {
+		// the cached checkpoint may not match the stored one anymore
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		delete(c.checkpoints, partition.key())
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checkpoints[partition.key()] = checkpoint
+	return nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	snapshot, ok := c.takeSnapshot(key)
+	if !ok {
+		return nil
+	}
+	if err := c.export(ctx, logger, snapshot.partition, snapshot.results); err != nil {
+		c.restoreSnapshot(snapshot)
+		return err
+	}
+	return nil
+}
+
+func (c *controller) export(ctx context.Context, logger logr.Logger, partition Partition, results []policyreportv1alpha2.PolicyReportResult) error {
+	previous, err := c.loadCheckpoint(ctx, partition)
+	if err != nil {
+		return err
+	}
+	changes, err := diff(partition.Namespace, previous, results, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	logger.V(4).Info("exporting results", "changes", len(changes))
+	delivered := previous.clone()
+	for i := 0; i < len(changes); i += c.batchSize {
+		end := i + c.batchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		batch := changes[i:end]
+		events := make([]CloudEvent, 0, len(batch))
+		for _, change := range batch {
+			events = append(events, change.event)
+		}
+		if err := c.sender.send(ctx, events); err != nil {
+			return err
+		}
+		for _, change := range batch {
+			if change.entry == nil {
+				delete(delivered, change.key)
+			} else {
+				delivered[change.key] = *change.entry
+			}
+		}
+		// save the checkpoint after every batch so that a restart doesn't send delivered batches again
+		if err := c.saveCheckpoint(ctx, partition, delivered.clone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type change struct {
+	key   string
+	entry *CheckpointEntry
+	event CloudEvent
+}
+
+func resultKey(result policyreportv1alpha2.PolicyReportResult) (string, *corev1.ObjectReference) {
+	key := result.Policy + "/" + result.Rule
+	if len(result.Resources) == 0 {
+		return key, nil
+	}
+	resource := result.Resources[0]
+	return key + "/" + string(resource.UID), &resource
+}
+
+func resultHash(result policyreportv1alpha2.PolicyReportResult) (string, error) {
+	// the timestamp changes on every scan, it doesn't make the result different
+	result.Timestamp = metav1.Timestamp{}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// diff computes the events needed to go from the previous checkpoint to the current results
+func diff(namespace string, previous Checkpoint, results []policyreportv1alpha2.PolicyReportResult, now time.Time) ([]change, error) {
+	var changes []change
+	current := sets.NewString()
+	for _, result := range results {
+		key, resource := resultKey(result)
+		hash, err := resultHash(result)
+		if err != nil {
+			return nil, err
+		}
+		current.Insert(key)
+		eventType := EventTypeNew
+		if entry, ok := previous[key]; ok {
+			if entry.Hash == hash {
+				continue
+			}
+			eventType = EventTypeChanged
+		}
+		timestamp := now
+		if result.Timestamp.Seconds != 0 {
+			timestamp = time.Unix(result.Timestamp.Seconds, int64(result.Timestamp.Nanos))
+		}
+		changes = append(changes, change{
+			key: key,
+			entry: &CheckpointEntry{
+				Hash:     hash,
+				Policy:   result.Policy,
+				Rule:     result.Rule,
+				Resource: resource,
+			},
+			event: newCloudEvent(eventType, namespace, key, hash, timestamp, result),
+		})
+	}
+	for key, entry := range previous {
+		if current.Has(key) {
+			continue
+		}
+		resolved := policyreportv1alpha2.PolicyReportResult{
+			Policy: entry.Policy,
+			Rule:   entry.Rule,
+		}
+		if entry.Resource != nil {
+			resolved.Resources = []corev1.ObjectReference{*entry.Resource}
+		}
+		changes = append(changes, change{
+			key:   key,
+			event: newCloudEvent(EventTypeResolved, namespace, key, entry.Hash, now, resolved),
+		})
+	}
+	// sort changes to send them in a stable order
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].key < changes[j].key
+	})
+	return changes, nil
+}
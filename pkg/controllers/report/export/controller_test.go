@@ -0,0 +1,174 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type endpoint struct {
+	lock     sync.Mutex
+	failures int
+	batches  [][]CloudEvent
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.failures > 0 {
+		e.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("Content-Type") != cloudEventsBatchType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	var events []CloudEvent
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	e.batches = append(e.batches, events)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (e *endpoint) types() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	var types []string
+	for _, batch := range e.batches {
+		for _, event := range batch {
+			types = append(types, event.Type)
+		}
+	}
+	return types
+}
+
+func newResult(policy, rule string, uid types.UID, result policyreportv1alpha2.PolicyResult) policyreportv1alpha2.PolicyReportResult {
+	return policyreportv1alpha2.PolicyReportResult{
+		Policy: policy,
+		Rule:   rule,
+		Result: result,
+		Resources: []corev1.ObjectReference{{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  "test",
+			Name:       string(uid),
+			UID:        uid,
+		}},
+	}
+}
+
+func newTestController(url string, batchSize int, store CheckpointStore) *controller {
+	c := NewController(url, batchSize, store).(*controller)
+	c.sender.backoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+	return c
+}
+
+func Test_Export(t *testing.T) {
+	ctx := context.TODO()
+	target := &endpoint{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	store := NewConfigMapStore(fake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno"))
+	c := newTestController(server.URL, 2, store)
+	results := []policyreportv1alpha2.PolicyReportResult{
+		newResult("pol", "rule", "a", policyreportv1alpha2.StatusPass),
+		newResult("pol", "rule", "b", policyreportv1alpha2.StatusFail),
+		newResult("pol", "rule", "c", policyreportv1alpha2.StatusFail),
+	}
+	assert.NilError(t, c.export(ctx, logr.Discard(), Partition{Namespace: "test"}, results))
+	assert.Equal(t, len(target.batches), 2)
+	assert.DeepEqual(t, target.types(), []string{EventTypeNew, EventTypeNew, EventTypeNew})
+	assert.Equal(t, target.batches[0][0].Source, "kyverno.io/reports/namespaces/test")
+	assert.Equal(t, target.batches[0][0].Subject, "pol/rule/a")
+	// unchanged results are not sent again
+	assert.NilError(t, c.export(ctx, logr.Discard(), Partition{Namespace: "test"}, results))
+	assert.Equal(t, len(target.batches), 2)
+	// a new controller starts from the persisted checkpoint
+	c = newTestController(server.URL, 2, store)
+	results[1].Result = policyreportv1alpha2.StatusPass
+	assert.NilError(t, c.export(ctx, logr.Discard(), Partition{Namespace: "test"}, results[:2]))
+	assert.Equal(t, len(target.batches), 3)
+	assert.DeepEqual(t, target.types()[3:], []string{EventTypeChanged, EventTypeResolved})
+	resolved := target.batches[2][1]
+	assert.Equal(t, resolved.Data.Policy, "pol")
+	assert.Equal(t, resolved.Data.Resources[0].UID, types.UID("c"))
+}
+
+func Test_ExportRetry(t *testing.T) {
+	ctx := context.TODO()
+	target := &endpoint{failures: 2}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	store := NewConfigMapStore(fake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno"))
+	c := newTestController(server.URL, 10, store)
+	results := []policyreportv1alpha2.PolicyReportResult{
+		newResult("pol", "rule", "a", policyreportv1alpha2.StatusFail),
+	}
+	assert.NilError(t, c.export(ctx, logr.Discard(), Partition{}, results))
+	assert.DeepEqual(t, target.types(), []string{EventTypeNew})
+	assert.Equal(t, target.batches[0][0].Source, "kyverno.io/reports/cluster")
+	// when retries are exhausted the checkpoint is not updated
+	target.failures = 5
+	assert.Assert(t, c.export(ctx, logr.Discard(), Partition{}, nil) != nil)
+	checkpoint, err := store.Load(ctx, Partition{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(checkpoint), 1)
+	// client errors are not retried
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	c = newTestController(notFound.URL, 10, store)
+	err = c.export(ctx, logr.Discard(), Partition{}, nil)
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func Test_ExportPartitions(t *testing.T) {
+	ctx := context.TODO()
+	target := &endpoint{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	client := fake.NewSimpleClientset().CoreV1().ConfigMaps("kyverno")
+	store := NewConfigMapStore(client)
+	c := newTestController(server.URL, 10, store)
+	pol1 := Partition{Namespace: "test", Policy: "cpol.kyverno.io/pol1"}
+	pol2 := Partition{Namespace: "test", Policy: "cpol.kyverno.io/pol2"}
+	assert.NilError(t, c.export(ctx, logr.Discard(), pol1, []policyreportv1alpha2.PolicyReportResult{
+		newResult("pol1", "rule", "a", policyreportv1alpha2.StatusFail),
+	}))
+	assert.NilError(t, c.export(ctx, logr.Discard(), pol2, []policyreportv1alpha2.PolicyReportResult{
+		newResult("pol2", "rule", "a", policyreportv1alpha2.StatusFail),
+	}))
+	assert.DeepEqual(t, target.types(), []string{EventTypeNew, EventTypeNew})
+	assert.Equal(t, target.batches[1][0].Source, "kyverno.io/reports/namespaces/test")
+	// each partition has its own checkpoint
+	checkpoint, err := store.Load(ctx, pol1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(checkpoint), 1)
+	_, ok := checkpoint["pol1/rule/a"]
+	assert.Assert(t, ok)
+	cms, err := client.List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(cms.Items), 2)
+	// the checkpoint of a partition without results is deleted
+	assert.NilError(t, c.export(ctx, logr.Discard(), pol1, nil))
+	assert.DeepEqual(t, target.types()[2:], []string{EventTypeResolved})
+	cms, err = client.List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(cms.Items), 1)
+	assert.Equal(t, cms.Items[0].Name, configMapName(pol2))
+}
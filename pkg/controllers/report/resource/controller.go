@@ -17,11 +17,13 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"golang.org/x/exp/slices"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	watchTools "k8s.io/client-go/tools/watch"
@@ -39,8 +41,22 @@ type Resource struct {
 	Namespace string
 	Name      string
 	Hash      string
+	// Owner is the controller owner reference of the resource, if any
+	Owner *metav1.OwnerReference
 }
 
+const (
+	// maxOwnerDepth bounds the owner references followed when looking for the top level owner of a resource
+	maxOwnerDepth = 10
+	// ownerCacheSize and ownerCacheTTL bound the cache of owner references of owners that are not watched,
+	// the cache holds one small entry per distinct owner so it is sized for large clusters
+	ownerCacheSize = 16384
+	ownerCacheTTL  = 10 * time.Minute
+	// ownerNotFoundTTL is how long owners that can't be fetched are remembered, so that deleted owners
+	// or owners kyverno is not allowed to read are not fetched again for every resource they own
+	ownerNotFoundTTL = time.Minute
+)
+
 type EventType string
 
 const (
@@ -54,6 +70,8 @@ type EventHandler func(EventType, types.UID, schema.GroupVersionKind, Resource)
 
 type MetadataCache interface {
 	GetResourceHash(uid types.UID) (Resource, schema.GroupVersionKind, bool)
+	// GetTopLevelOwner follows the controller owner references of a resource and returns the top most one
+	GetTopLevelOwner(ctx context.Context, uid types.UID) (metav1.OwnerReference, bool)
 	AddEventHandler(EventHandler)
 	Warmup(ctx context.Context) error
 }
@@ -83,6 +101,7 @@ type controller struct {
 	lock            sync.RWMutex
 	dynamicWatchers map[schema.GroupVersionResource]*watcher
 	eventHandlers   []EventHandler
+	owners          *utilcache.LRUExpireCache
 }
 
 func NewController(
@@ -96,6 +115,7 @@ func NewController(
 		cpolLister:      cpolInformer.Lister(),
 		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		dynamicWatchers: map[schema.GroupVersionResource]*watcher{},
+		owners:          utilcache.NewLRUExpireCache(ownerCacheSize),
 	}
 	controllerutils.AddDefaultEventHandlers(logger, polInformer.Informer(), c.queue)
 	controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue)
@@ -122,6 +142,56 @@ func (c *controller) GetResourceHash(uid types.UID) (Resource, schema.GroupVersi
 	return Resource{}, schema.GroupVersionKind{}, false
 }
 
+func (c *controller) GetTopLevelOwner(ctx context.Context, uid types.UID) (metav1.OwnerReference, bool) {
+	resource, _, exists := c.GetResourceHash(uid)
+	if !exists || resource.Owner == nil {
+		return metav1.OwnerReference{}, false
+	}
+	owner := *resource.Owner
+	for i := 1; i < maxOwnerDepth; i++ {
+		next, err := c.getOwner(ctx, resource.Namespace, owner)
+		if err != nil || next == nil {
+			break
+		}
+		owner = *next
+	}
+	return owner, true
+}
+
+// getOwner returns the controller owner reference of an owner, owners that are not watched
+// are fetched and their owner reference is cached
+func (c *controller) getOwner(ctx context.Context, namespace string, ref metav1.OwnerReference) (*metav1.OwnerReference, error) {
+	if resource, _, exists := c.GetResourceHash(ref.UID); exists {
+		return resource.Owner, nil
+	}
+	if owner, ok := c.owners.Get(ref.UID); ok {
+		return owner.(*metav1.OwnerReference), nil
+	}
+	obj, err := c.client.GetResource(ctx, ref.APIVersion, ref.Kind, namespace, ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			c.owners.Add(ref.UID, (*metav1.OwnerReference)(nil), ownerNotFoundTTL)
+		}
+		return nil, err
+	}
+	var owner *metav1.OwnerReference
+	// the owner may have been recreated with the same name
+	if obj.GetUID() == ref.UID {
+		owner = metav1.GetControllerOf(obj)
+	}
+	c.owners.Add(ref.UID, owner, ownerCacheTTL)
+	return owner, nil
+}
+
+func newResource(obj unstructured.Unstructured) Resource {
+	return Resource{
+		Hash:      reportutils.CalculateResourceHash(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Owner:     metav1.GetControllerOf(&obj),
+	}
+}
+
 func (c *controller) AddEventHandler(eventHandler EventHandler) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -143,12 +213,7 @@ func (c *controller) startWatcher(ctx context.Context, logger logr.Logger, gvr s
 		resourceVersion := objs.GetResourceVersion()
 		for _, obj := range objs.Items {
 			uid := obj.GetUID()
-			hash := reportutils.CalculateResourceHash(obj)
-			hashes[uid] = Resource{
-				Hash:      hash,
-				Namespace: obj.GetNamespace(),
-				Name:      obj.GetName(),
-			}
+			hashes[uid] = newResource(obj)
 			c.notify(Added, uid, gvk, hashes[uid])
 		}
 		logger := logger.WithValues("resourceVersion", resourceVersion)
@@ -271,14 +336,12 @@ func (c *controller) updateHash(eventType EventType, obj *unstructured.Unstructu
 	watcher, exists := c.dynamicWatchers[gvr]
 	if exists {
 		uid := obj.GetUID()
-		hash := reportutils.CalculateResourceHash(*obj)
-		if exists && hash != watcher.hashes[uid].Hash {
-			watcher.hashes[uid] = Resource{
-				Hash:      hash,
-				Namespace: obj.GetNamespace(),
-				Name:      obj.GetName(),
-			}
-			c.notify(eventType, uid, watcher.gvk, watcher.hashes[uid])
+		resource := newResource(*obj)
+		previous := watcher.hashes[uid]
+		// owner references are not part of the hash, they are kept up to date without notifying
+		watcher.hashes[uid] = resource
+		if resource.Hash != previous.Hash {
+			c.notify(eventType, uid, watcher.gvk, resource)
 		}
 	}
 }
@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+)
+
+func Test_GetOwnerNotFound(t *testing.T) {
+	c := controller{
+		client:          dclient.NewEmptyFakeClient(),
+		dynamicWatchers: map[schema.GroupVersionResource]*watcher{},
+		owners:          utilcache.NewLRUExpireCache(ownerCacheSize),
+	}
+	ref := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app", UID: "app"}
+	_, err := c.getOwner(context.TODO(), "test", ref)
+	assert.ErrorContains(t, err, "not found")
+	// missing owners are remembered and not fetched again
+	cached, ok := c.owners.Get(ref.UID)
+	assert.Assert(t, ok)
+	assert.Assert(t, cached.(*metav1.OwnerReference) == nil)
+	owner, err := c.getOwner(context.TODO(), "test", ref)
+	assert.NilError(t, err)
+	assert.Assert(t, owner == nil)
+}
@@ -0,0 +1,295 @@
+package scanrequest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "scan-request-controller"
+	maxRetries     = 10
+)
+
+const (
+	// AnnotationScanRequest is the namespace annotation requesting a background scan of the namespace,
+	// any new value triggers a new scan, requests on the kyverno namespace also rescan cluster reports
+	AnnotationScanRequest = "kyverno.io/scan-request"
+	// AnnotationScanRequestPolicies optionally restricts the scan to a comma separated list of policy names
+	AnnotationScanRequestPolicies = "kyverno.io/scan-request-policies"
+	// AnnotationScanRequestStatus is the namespace annotation containing the status of the last scan request
+	AnnotationScanRequestStatus = "kyverno.io/scan-request-status"
+)
+
+const (
+	// PhaseRunning means reports are waiting to be rescanned
+	PhaseRunning = "Running"
+	// PhaseCompleted means all reports have been rescanned
+	PhaseCompleted = "Completed"
+)
+
+// Status is the status of a scan request
+type Status struct {
+	// Request is the value of the scan request annotation this status applies to
+	Request string `json:"request"`
+	// Phase is either Running or Completed
+	Phase string `json:"phase"`
+	// Policies is the list of policies the scan is restricted to, if any
+	Policies []string `json:"policies,omitempty"`
+	// Total is the number of background scan reports to rescan
+	Total int `json:"total"`
+	// Scanned is the number of background scan reports already rescanned
+	Scanned int `json:"scanned"`
+	// StartTime is the time the scan was requested
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the last report was rescanned
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// controller rescans the background scan reports of a namespace when the scan request annotation
+// of the namespace changes, the reports are marked with a label removed by the background scan
+// controller once they are rescanned so that progress can be tracked in the namespace annotations
+type controller struct {
+	// clients
+	client        kubernetes.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	nsLister corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client kubernetes.Interface,
+	kyvernoClient versioned.Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	nsInformer corev1informers.NamespaceInformer,
+) controllers.Controller {
+	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
+	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		nsLister:      nsInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+	controllerutils.AddEventHandlersT(nsInformer.Informer(), c.addNamespace, c.updateNamespace, c.deleteNamespace)
+	controllerutils.AddEventHandlers(bgscanr.Informer(), c.addReport, c.updateReport, c.deleteReport)
+	controllerutils.AddEventHandlers(cbgscanr.Informer(), c.addReport, c.updateReport, c.deleteReport)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) addNamespace(obj *corev1.Namespace) {
+	if _, ok := obj.GetAnnotations()[AnnotationScanRequest]; ok {
+		c.queue.Add(obj.GetName())
+	}
+}
+
+func (c *controller) updateNamespace(_, obj *corev1.Namespace) {
+	c.addNamespace(obj)
+}
+
+func (c *controller) deleteNamespace(_ *corev1.Namespace) {
+	// nothing to do, reports are deleted with the namespace
+}
+
+func (c *controller) addReport(obj interface{}) {
+	if report, ok := obj.(metav1.Object); ok && controllerutils.HasLabel(report, reportutils.LabelScanRequested) {
+		if report.GetNamespace() == "" {
+			// cluster reports are rescanned by requests on the kyverno namespace
+			c.queue.Add(config.KyvernoNamespace())
+		} else {
+			c.queue.Add(report.GetNamespace())
+		}
+	}
+}
+
+func (c *controller) updateReport(old, obj interface{}) {
+	c.addReport(old)
+	c.addReport(obj)
+}
+
+func (c *controller) deleteReport(obj interface{}) {
+	c.addReport(obj)
+}
+
+// GetStatus returns the status of the last scan request of a namespace, if any
+func GetStatus(ns *corev1.Namespace) (*Status, error) {
+	data, ok := ns.GetAnnotations()[AnnotationScanRequestStatus]
+	if !ok {
+		return nil, nil
+	}
+	var status Status
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func parsePolicies(ns *corev1.Namespace) []string {
+	var policies []string
+	for _, policy := range strings.Split(ns.GetAnnotations()[AnnotationScanRequestPolicies], ",") {
+		if policy = strings.TrimSpace(policy); policy != "" {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	ns, err := c.nsLister.Get(key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	request := ns.GetAnnotations()[AnnotationScanRequest]
+	if request == "" {
+		return nil
+	}
+	status, err := GetStatus(ns)
+	if err != nil {
+		logger.Error(err, "failed to decode scan request status, starting a new scan")
+	}
+	now := metav1.Now()
+	if status == nil || status.Request != request {
+		policies := parsePolicies(ns)
+		total, err := c.requestScan(ctx, key, policies)
+		if err != nil {
+			return err
+		}
+		logger.V(2).Info("background scan requested", "request", request, "reports", total, "policies", policies)
+		// progress is computed on the next report events
+		status = &Status{
+			Request:   request,
+			Phase:     PhaseRunning,
+			Policies:  policies,
+			Total:     total,
+			StartTime: now,
+		}
+	} else if status.Phase == PhaseRunning {
+		pending, err := c.countPending(ctx, key)
+		if err != nil {
+			return err
+		}
+		status.Scanned = status.Total - pending
+		if status.Scanned < 0 {
+			status.Scanned = 0
+		}
+	}
+	if status.Phase == PhaseRunning && status.Scanned >= status.Total {
+		status.Phase = PhaseCompleted
+		status.Scanned = status.Total
+		status.CompletionTime = &now
+	}
+	return c.setStatus(ctx, ns, status)
+}
+
+// requestScan marks the background scan reports of a namespace for rescan, either for all policies
+// by dropping the resource hash label or for the given policies by dropping their labels,
+// cluster background scan reports are marked too when the namespace is the kyverno namespace
+func (c *controller) requestScan(ctx context.Context, namespace string, policies []string) (int, error) {
+	mark := func(report metav1.Object) {
+		reportLabels := controllerutils.SetLabel(report, reportutils.LabelScanRequested, "true")
+		if len(policies) == 0 {
+			delete(reportLabels, reportutils.LabelResourceHash)
+		} else {
+			for _, policy := range policies {
+				delete(reportLabels, reportutils.LabelPrefixPolicy+policy)
+				delete(reportLabels, reportutils.LabelPrefixClusterPolicy+policy)
+			}
+		}
+	}
+	client := c.kyvernoClient.KyvernoV1alpha2().BackgroundScanReports(namespace)
+	reports, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+	total := len(reports.Items)
+	for i := range reports.Items {
+		_, err := controllerutils.Update(ctx, &reports.Items[i], client, func(report *kyvernov1alpha2.BackgroundScanReport) error {
+			mark(report)
+			return nil
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+	}
+	if namespace == config.KyvernoNamespace() {
+		client := c.kyvernoClient.KyvernoV1alpha2().ClusterBackgroundScanReports()
+		reports, err := client.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, err
+		}
+		total += len(reports.Items)
+		for i := range reports.Items {
+			_, err := controllerutils.Update(ctx, &reports.Items[i], client, func(report *kyvernov1alpha2.ClusterBackgroundScanReport) error {
+				mark(report)
+				return nil
+			})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return 0, err
+			}
+		}
+	}
+	return total, nil
+}
+
+// countPending returns the number of background scan reports of a namespace waiting to be rescanned,
+// reports are read from the api server as the informer cache may not see the marked reports yet
+func (c *controller) countPending(ctx context.Context, namespace string) (int, error) {
+	selector := labels.SelectorFromSet(labels.Set{reportutils.LabelScanRequested: "true"})
+	options := metav1.ListOptions{LabelSelector: selector.String()}
+	reports, err := c.kyvernoClient.KyvernoV1alpha2().BackgroundScanReports(namespace).List(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+	pending := len(reports.Items)
+	if namespace == config.KyvernoNamespace() {
+		reports, err := c.kyvernoClient.KyvernoV1alpha2().ClusterBackgroundScanReports().List(ctx, options)
+		if err != nil {
+			return 0, err
+		}
+		pending += len(reports.Items)
+	}
+	return pending, nil
+}
+
+func (c *controller) setStatus(ctx context.Context, ns *corev1.Namespace, status *Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	_, err = controllerutils.Update(ctx, ns, c.client.CoreV1().Namespaces(), func(ns *corev1.Namespace) error {
+		controllerutils.SetAnnotation(ns, AnnotationScanRequestStatus, string(data))
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,161 @@
+package scanrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/config"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newReport(name string) *kyvernov1alpha2.BackgroundScanReport {
+	return &kyvernov1alpha2.BackgroundScanReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				reportutils.LabelResourceHash:                 "hash",
+				reportutils.LabelPrefixClusterPolicy + "pol1": "1",
+				reportutils.LabelPrefixClusterPolicy + "pol2": "2",
+			},
+		},
+	}
+}
+
+func Test_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "test",
+		Annotations: map[string]string{
+			AnnotationScanRequest:         "1",
+			AnnotationScanRequestPolicies: "pol1",
+		},
+	}}
+	client := fake.NewSimpleClientset(ns)
+	kyvernoClient := versionedfake.NewSimpleClientset(newReport("a"), newReport("b"))
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		nsLister:      corev1listers.NewNamespaceLister(nsIndexer),
+	}
+	// syncs the namespace lister with the fake client
+	sync := func() {
+		ns, err := client.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.NilError(t, nsIndexer.Update(ns))
+	}
+	status := func() *Status {
+		sync()
+		ns, err := c.nsLister.Get("test")
+		assert.NilError(t, err)
+		status, err := GetStatus(ns)
+		assert.NilError(t, err)
+		return status
+	}
+	// scan is requested for the given policy
+	sync()
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	s := status()
+	assert.Equal(t, s.Phase, PhaseRunning)
+	assert.Equal(t, s.Total, 2)
+	assert.Equal(t, s.Scanned, 0)
+	report, err := kyvernoClient.KyvernoV1alpha2().BackgroundScanReports("test").Get(ctx, "a", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, report.GetLabels()[reportutils.LabelScanRequested], "true")
+	assert.Equal(t, report.GetLabels()[reportutils.LabelResourceHash], "hash")
+	_, ok := report.GetLabels()[reportutils.LabelPrefixClusterPolicy+"pol1"]
+	assert.Assert(t, !ok)
+	_, ok = report.GetLabels()[reportutils.LabelPrefixClusterPolicy+"pol2"]
+	assert.Assert(t, ok)
+	// reports are still pending
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	s = status()
+	assert.Equal(t, s.Phase, PhaseRunning)
+	assert.Equal(t, s.Scanned, 0)
+	// one report is rescanned
+	delete(report.Labels, reportutils.LabelScanRequested)
+	_, err = kyvernoClient.KyvernoV1alpha2().BackgroundScanReports("test").Update(ctx, report, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	sync()
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	s = status()
+	assert.Equal(t, s.Phase, PhaseRunning)
+	assert.Equal(t, s.Scanned, 1)
+	// both reports are rescanned
+	report, err = kyvernoClient.KyvernoV1alpha2().BackgroundScanReports("test").Get(ctx, "b", metav1.GetOptions{})
+	assert.NilError(t, err)
+	delete(report.Labels, reportutils.LabelScanRequested)
+	_, err = kyvernoClient.KyvernoV1alpha2().BackgroundScanReports("test").Update(ctx, report, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	sync()
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	s = status()
+	assert.Equal(t, s.Phase, PhaseCompleted)
+	assert.Equal(t, s.Scanned, 2)
+	assert.Assert(t, s.CompletionTime != nil)
+	// a new request triggers a full scan
+	ns, err = client.CoreV1().Namespaces().Get(ctx, "test", metav1.GetOptions{})
+	assert.NilError(t, err)
+	ns.Annotations[AnnotationScanRequest] = "2"
+	delete(ns.Annotations, AnnotationScanRequestPolicies)
+	_, err = client.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+	sync()
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), "test", "", "test"))
+	s = status()
+	assert.Equal(t, s.Request, "2")
+	assert.Equal(t, s.Phase, PhaseRunning)
+	report, err = kyvernoClient.KyvernoV1alpha2().BackgroundScanReports("test").Get(ctx, "b", metav1.GetOptions{})
+	assert.NilError(t, err)
+	_, ok = report.GetLabels()[reportutils.LabelResourceHash]
+	assert.Assert(t, !ok)
+}
+
+func Test_ReconcileCluster(t *testing.T) {
+	ctx := context.TODO()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: config.KyvernoNamespace(),
+		Annotations: map[string]string{
+			AnnotationScanRequest: "1",
+		},
+	}}
+	report := &kyvernov1alpha2.ClusterBackgroundScanReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "a",
+			Labels: map[string]string{
+				reportutils.LabelResourceHash: "hash",
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(ns)
+	kyvernoClient := versionedfake.NewSimpleClientset(report)
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, nsIndexer.Add(ns))
+	c := controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		nsLister:      corev1listers.NewNamespaceLister(nsIndexer),
+	}
+	assert.NilError(t, c.reconcile(ctx, logr.Discard(), ns.Name, "", ns.Name))
+	report, err := kyvernoClient.KyvernoV1alpha2().ClusterBackgroundScanReports().Get(ctx, "a", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, report.GetLabels()[reportutils.LabelScanRequested], "true")
+	_, ok := report.GetLabels()[reportutils.LabelResourceHash]
+	assert.Assert(t, !ok)
+	ns, err = client.CoreV1().Namespaces().Get(ctx, ns.Name, metav1.GetOptions{})
+	assert.NilError(t, err)
+	s, err := GetStatus(ns)
+	assert.NilError(t, err)
+	assert.Equal(t, s.Phase, PhaseRunning)
+	assert.Equal(t, s.Total, 1)
+}
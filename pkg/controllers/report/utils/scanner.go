@@ -26,6 +26,8 @@ type scanner struct {
 type ScanResult struct {
 	EngineResponse *response.EngineResponse
 	Error          error
+	// ConfigMaps contains the namespace/name keys of the config maps read by the policy
+	ConfigMaps []string
 }
 
 type Scanner interface {
@@ -46,14 +48,20 @@ func (s *scanner) ScanResource(ctx context.Context, resource unstructured.Unstru
 	results := map[kyvernov1.PolicyInterface]ScanResult{}
 	for _, policy := range policies {
 		var errors []error
-		response, err := s.validateResource(ctx, resource, nsLabels, policy)
+		cmResolver := s.informerCacheResolvers
+		var recorder resolvers.RecordingResolver
+		if cmResolver != nil {
+			recorder, _ = resolvers.NewRecordingResolver(cmResolver)
+			cmResolver = recorder
+		}
+		response, err := s.validateResource(ctx, resource, nsLabels, policy, cmResolver)
 		if err != nil {
 			s.logger.Error(err, "failed to scan resource")
 			errors = append(errors, err)
 		}
 		spec := policy.GetSpec()
 		if spec.HasVerifyImages() {
-			ivResponse, err := s.validateImages(ctx, resource, nsLabels, policy)
+			ivResponse, err := s.validateImages(ctx, resource, nsLabels, policy, cmResolver)
 			if err != nil {
 				s.logger.Error(err, "failed to scan images")
 				errors = append(errors, err)
@@ -64,12 +72,16 @@ func (s *scanner) ScanResource(ctx context.Context, resource unstructured.Unstru
 				response.PolicyResponse.Rules = append(response.PolicyResponse.Rules, ivResponse.PolicyResponse.Rules...)
 			}
 		}
-		results[policy] = ScanResult{response, multierr.Combine(errors...)}
+		result := ScanResult{EngineResponse: response, Error: multierr.Combine(errors...)}
+		if recorder != nil {
+			result.ConfigMaps = recorder.ConfigMaps()
+		}
+		results[policy] = result
 	}
 	return results
 }
 
-func (s *scanner) validateResource(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface) (*response.EngineResponse, error) {
+func (s *scanner) validateResource(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface, cmResolver resolvers.ConfigmapResolver) (*response.EngineResponse, error) {
 	enginectx := enginecontext.NewContext()
 	if err := enginectx.AddResource(resource.Object); err != nil {
 		return nil, err
@@ -89,11 +101,11 @@ func (s *scanner) validateResource(ctx context.Context, resource unstructured.Un
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
 		WithExcludeGroupRole(s.excludeGroupRole...).
-		WithInformerCacheResolver(s.informerCacheResolvers)
+		WithInformerCacheResolver(cmResolver)
 	return engine.Validate(ctx, s.rclient, policyCtx), nil
 }
 
-func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface) (*response.EngineResponse, error) {
+func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface, cmResolver resolvers.ConfigmapResolver) (*response.EngineResponse, error) {
 	enginectx := enginecontext.NewContext()
 	if err := enginectx.AddResource(resource.Object); err != nil {
 		return nil, err
@@ -113,7 +125,7 @@ func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unst
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
 		WithExcludeGroupRole(s.excludeGroupRole...).
-		WithInformerCacheResolver(s.informerCacheResolvers)
+		WithInformerCacheResolver(cmResolver)
 	response, _ := engine.VerifyAndPatchImages(ctx, s.rclient, policyCtx)
 	if len(response.PolicyResponse.Rules) > 0 {
 		s.logger.Info("validateImages", "policy", policy, "response", response)
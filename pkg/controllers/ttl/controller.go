@@ -0,0 +1,132 @@
+package ttl
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// controller deletes the resources of a single kind when their TTL label expires
+type controller struct {
+	// clients
+	client metadata.Interface
+
+	// informer
+	informer cache.SharedIndexInformer
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	gvr     schema.GroupVersionResource
+	kind    string
+	metrics metrics.MetricsConfigManager
+}
+
+func newController(client metadata.Interface, gvr schema.GroupVersionResource, kind string, metricsConfig metrics.MetricsConfigManager) *controller {
+	informer := metadatainformer.NewFilteredMetadataInformer(
+		client,
+		gvr,
+		metav1.NamespaceAll,
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = cleanup.TTLLabel
+		},
+	).Informer()
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName+"-"+gvr.String())
+	c := &controller{
+		client:   client,
+		informer: informer,
+		queue:    queue,
+		gvr:      gvr,
+		kind:     kind,
+		metrics:  metricsConfig,
+	}
+	controllerutils.AddEventHandlers(
+		informer,
+		func(obj interface{}) { c.enqueue(obj) },
+		func(_, obj interface{}) { c.enqueue(obj) },
+		func(interface{}) {},
+	)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	logger := logger.WithValues("gvr", c.gvr.String())
+	go c.informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced) {
+		logger.Info("failed to wait for cache sync")
+		return
+	}
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(err, "failed to compute key", "gvr", c.gvr.String())
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	obj, exists, err := c.informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return nil
+	}
+	expiry, found, err := cleanup.GetExpiry(metaObj)
+	if err != nil {
+		logger.Error(err, "invalid TTL, the resource will not be deleted")
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	if wait := time.Until(expiry); wait > 0 {
+		logger.V(4).Info("resource not expired yet", "expiry", expiry)
+		c.queue.AddAfter(key, wait)
+		return nil
+	}
+	logger.Info("resource expired, deleting it", "expiry", expiry)
+	uid := metaObj.GetUID()
+	err = c.client.Resource(c.gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		// don't delete the resource if it was recreated in the meantime
+		Preconditions: &metav1.Preconditions{UID: &uid},
+	})
+	if err != nil {
+		// the resource was already deleted or was recreated
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			return nil
+		}
+		c.recordDeletion(ctx, namespace, metrics.CleanupFailed)
+		return err
+	}
+	c.recordDeletion(ctx, namespace, metrics.CleanupDeleted)
+	return nil
+}
+
+func (c *controller) recordDeletion(ctx context.Context, namespace string, result metrics.CleanupResult) {
+	if c.metrics != nil && c.metrics.Config().CheckNamespace(namespace) {
+		c.metrics.RecordTTLDeletions(ctx, c.kind, namespace, result)
+	}
+}
@@ -0,0 +1,206 @@
+package ttl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"golang.org/x/exp/slices"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/metadata"
+)
+
+const (
+	// Workers is the number of workers per resource kind
+	Workers        = 1
+	ControllerName = "ttl-controller"
+	maxRetries     = 10
+	resyncPeriod   = 15 * time.Minute
+	// discoveryInterval is how often new resource kinds and permissions are discovered
+	discoveryInterval = 5 * time.Minute
+)
+
+// requiredVerbs are the verbs needed on a resource kind to delete its expired resources
+var requiredVerbs = []string{"list", "watch", "delete"}
+
+type watchedResource struct {
+	kind   string
+	cancel context.CancelFunc
+}
+
+type manager struct {
+	// clients
+	metadataClient  metadata.Interface
+	discoveryClient discovery.DiscoveryInterface
+	rulesClient     authorizationv1client.SelfSubjectRulesReviewInterface
+
+	// config
+	metrics metrics.MetricsConfigManager
+
+	lock      sync.Mutex
+	resources map[schema.GroupVersionResource]watchedResource
+}
+
+// NewManager returns a controller deleting resources with an expired TTL label, it watches
+// every resource kind the controller has permission to list, watch and delete
+func NewManager(
+	metadataClient metadata.Interface,
+	discoveryClient discovery.DiscoveryInterface,
+	rulesClient authorizationv1client.SelfSubjectRulesReviewInterface,
+	metricsConfig metrics.MetricsConfigManager,
+) controllers.Controller {
+	return &manager{
+		metadataClient:  metadataClient,
+		discoveryClient: discoveryClient,
+		rulesClient:     rulesClient,
+		metrics:         metricsConfig,
+		resources:       map[schema.GroupVersionResource]watchedResource{},
+	}
+}
+
+func (m *manager) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer m.stop()
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.reconcile(ctx, &wg, workers); err != nil {
+			logger.Error(err, "failed to discover resources")
+		}
+	}, discoveryInterval)
+}
+
+func (m *manager) reconcile(ctx context.Context, wg *sync.WaitGroup, workers int) error {
+	desired, err := m.discover(ctx)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr, resource := range m.resources {
+		if _, ok := desired[gvr]; !ok {
+			logger.Info("stop watching resource", "gvr", gvr.String())
+			resource.cancel()
+			delete(m.resources, gvr)
+		}
+	}
+	for gvr, kind := range desired {
+		if _, ok := m.resources[gvr]; ok {
+			continue
+		}
+		logger.Info("start watching resource", "gvr", gvr.String())
+		ctx, cancel := context.WithCancel(ctx)
+		m.resources[gvr] = watchedResource{kind: kind, cancel: cancel}
+		controller := newController(m.metadataClient, gvr, kind, m.metrics)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			controller.Run(ctx, workers)
+		}()
+	}
+	return nil
+}
+
+func (m *manager) stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr, resource := range m.resources {
+		resource.cancel()
+		delete(m.resources, gvr)
+	}
+}
+
+// discover returns the resources that support list, watch and delete and that the controller is allowed to delete
+func (m *manager) discover(ctx context.Context) (map[schema.GroupVersionResource]string, error) {
+	resources, err := discovery.ServerPreferredResources(m.discoveryClient)
+	if err != nil {
+		if discovery.IsGroupDiscoveryFailedError(err) {
+			for gv, err := range err.(*discovery.ErrGroupDiscoveryFailed).Groups {
+				logger.Error(err, "failed to list api resources", "group", gv)
+			}
+		} else {
+			return nil, err
+		}
+	}
+	rules, err := m.resourceRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gvrs := map[schema.GroupVersionResource]string{}
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			logger.Error(err, "failed to parse group version", "groupVersion", list.GroupVersion)
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if !supportsVerbs(resource) {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			if isAllowed(rules, gvr) {
+				gvrs[gvr] = resource.Kind
+			} else {
+				logger.V(4).Info("not allowed", "gvr", gvr.String())
+			}
+		}
+	}
+	return gvrs, nil
+}
+
+func supportsVerbs(resource metav1.APIResource) bool {
+	for _, verb := range requiredVerbs {
+		if !slices.Contains(resource.Verbs, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceRules returns the resource rules of the controller with a single review, cluster wide
+// permissions are granted through cluster role bindings and are returned for any namespace
+func (m *manager) resourceRules(ctx context.Context) ([]authorizationv1.ResourceRule, error) {
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+			Namespace: config.KyvernoNamespace(),
+		},
+	}
+	response, err := m.rulesClient.Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status.Incomplete {
+		logger.Info("resource rules may be incomplete", "error", response.Status.EvaluationError)
+	}
+	return response.Status.ResourceRules, nil
+}
+
+func isAllowed(rules []authorizationv1.ResourceRule, gvr schema.GroupVersionResource) bool {
+	for _, verb := range requiredVerbs {
+		if !slices.ContainsFunc(rules, func(rule authorizationv1.ResourceRule) bool {
+			return ruleAllows(rule, gvr, verb)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func ruleAllows(rule authorizationv1.ResourceRule, gvr schema.GroupVersionResource, verb string) bool {
+	// rules restricted to resource names don't allow listing or watching the whole kind
+	if len(rule.ResourceNames) != 0 {
+		return false
+	}
+	return matches(rule.Verbs, verb) && matches(rule.APIGroups, gvr.Group) && matches(rule.Resources, gvr.Resource)
+}
+
+func matches(values []string, value string) bool {
+	return slices.Contains(values, "*") || slices.Contains(values, value)
+}
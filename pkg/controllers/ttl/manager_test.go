@@ -0,0 +1,79 @@
+package ttl
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_isAllowed(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	jobs := schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	tests := []struct {
+		name  string
+		rules []authorizationv1.ResourceRule
+		gvr   schema.GroupVersionResource
+		want  bool
+	}{{
+		name: "no rules",
+		gvr:  pods,
+		want: false,
+	}, {
+		name: "all verbs",
+		rules: []authorizationv1.ResourceRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"list", "watch", "delete"},
+		}},
+		gvr:  pods,
+		want: true,
+	}, {
+		name: "verbs split across rules",
+		rules: []authorizationv1.ResourceRule{{
+			APIGroups: []string{"batch"},
+			Resources: []string{"jobs"},
+			Verbs:     []string{"list", "watch"},
+		}, {
+			APIGroups: []string{"*"},
+			Resources: []string{"*"},
+			Verbs:     []string{"delete"},
+		}},
+		gvr:  jobs,
+		want: true,
+	}, {
+		name: "missing verb",
+		rules: []authorizationv1.ResourceRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"list", "watch"},
+		}},
+		gvr:  pods,
+		want: false,
+	}, {
+		name: "other group",
+		rules: []authorizationv1.ResourceRule{{
+			APIGroups: []string{""},
+			Resources: []string{"jobs"},
+			Verbs:     []string{"*"},
+		}},
+		gvr:  jobs,
+		want: false,
+	}, {
+		name: "resource names",
+		rules: []authorizationv1.ResourceRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"pods"},
+			ResourceNames: []string{"foo"},
+			Verbs:         []string{"*"},
+		}},
+		gvr:  pods,
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, isAllowed(tt.rules, tt.gvr), tt.want)
+		})
+	}
+}
@@ -118,6 +118,11 @@ func filterRule(rclient registryclient.Client, rule kyvernov1.Rule, policyContex
 		return nil
 	}
 
+	// check if there is a corresponding policy exception
+	if ruleResp := hasPolicyExceptions(logger, ruleType, policyContext, &rule); ruleResp != nil {
+		return ruleResp
+	}
+
 	ruleCopy := rule.DeepCopy()
 	if after, err := variables.SubstituteAllInPreconditions(logger, ctx, ruleCopy.GetAnyAllConditions()); err != nil {
 		logger.V(4).Info("failed to substitute vars in preconditions, skip current rule", "rule name", ruleCopy.Name)
@@ -3,9 +3,11 @@ package resolvers
 import (
 	"context"
 	"errors"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -68,3 +70,40 @@ func (chain resolverChain) Get(ctx context.Context, namespace, name string) (*co
 	}
 	return nil, lastErr
 }
+
+// RecordingResolver is a config map resolver keeping track of the config maps that were requested
+type RecordingResolver interface {
+	ConfigmapResolver
+	// ConfigMaps returns the sorted namespace/name keys of the config maps requested so far,
+	// including the ones that could not be found
+	ConfigMaps() []string
+}
+
+type recordingResolver struct {
+	inner      ConfigmapResolver
+	lock       sync.Mutex
+	configMaps sets.String
+}
+
+func NewRecordingResolver(inner ConfigmapResolver) (RecordingResolver, error) {
+	if inner == nil {
+		return nil, errors.New("resolver must not be nil")
+	}
+	return &recordingResolver{
+		inner:      inner,
+		configMaps: sets.NewString(),
+	}, nil
+}
+
+func (r *recordingResolver) Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	r.lock.Lock()
+	r.configMaps.Insert(namespace + "/" + name)
+	r.lock.Unlock()
+	return r.inner.Get(ctx, namespace, name)
+}
+
+func (r *recordingResolver) ConfigMaps() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.configMaps.List()
+}
@@ -228,3 +228,21 @@ func TestNewResolverChain(t *testing.T) {
 		})
 	}
 }
+
+func Test_RecordingResolver(t *testing.T) {
+	client := newEmptyFakeClient()
+	ctx := context.TODO()
+	err := createConfigMaps(ctx, client, false)
+	assert.NilError(t, err, "error while creating configmap")
+	clientBasedResolver, err := NewClientBasedResolver(client)
+	assert.NilError(t, err)
+	resolver, err := NewRecordingResolver(clientBasedResolver)
+	assert.NilError(t, err)
+	_, err = resolver.Get(ctx, TEST_NAMESPACE, TEST_CONFIGMAP)
+	assert.NilError(t, err, "error while getting configmap")
+	_, err = resolver.Get(ctx, TEST_NAMESPACE, "missing")
+	assert.Error(t, err, "configmaps \"missing\" not found")
+	_, err = resolver.Get(ctx, TEST_NAMESPACE, TEST_CONFIGMAP)
+	assert.NilError(t, err, "error while getting configmap")
+	assert.DeepEqual(t, resolver.ConfigMaps(), []string{"default/missing", "default/myconfigmap"})
+}
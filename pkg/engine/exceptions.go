@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	matched "github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/client-go/tools/cache"
+)
+
+// matchesExceptions returns the exceptions applying to the resource being admitted
+func matchesExceptions(logger logr.Logger, policyContext *PolicyContext, rule *kyvernov1.Rule) ([]*kyvernov2alpha1.PolicyException, error) {
+	candidates, err := policyContext.FindExceptions(rule.Name)
+	if err != nil {
+		return nil, err
+	}
+	var result []*kyvernov2alpha1.PolicyException
+	for _, candidate := range candidates {
+		err := matched.CheckMatchesResources(policyContext.newResource, candidate.Spec.Match, policyContext.namespaceLabels)
+		// if there's no error it means a match
+		if err != nil {
+			continue
+		}
+		if candidate.Spec.Conditions != nil {
+			passed, err := variables.CheckAnyAllConditions(logger, policyContext.jsonContext, *candidate.Spec.Conditions)
+			if err != nil {
+				logger.Error(err, "failed to evaluate policy exception conditions", "namespace", candidate.GetNamespace(), "name", candidate.GetName())
+				continue
+			}
+			if !passed {
+				continue
+			}
+		}
+		result = append(result, candidate)
+	}
+	return result, nil
+}
+
+// hasPolicyExceptions returns a skip rule response if a policy exception applies to the resource being processed
+func hasPolicyExceptions(logger logr.Logger, ruleType response.RuleType, policyContext *PolicyContext, rule *kyvernov1.Rule) *response.RuleResponse {
+	// if matches, check if there is a corresponding policy exception
+	exceptions, err := matchesExceptions(logger, policyContext, rule)
+	if err != nil {
+		return nil
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.policy)
+	if err != nil {
+		logger.Error(err, "failed to compute policy key")
+		return nil
+	}
+	for _, exception := range exceptions {
+		// exceptions restricted to pod security controls don't skip the rule
+		if !exception.Spec.ExcludesRule(policyName, rule.Name) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(exception)
+		// TODO: increase metrics
+		if err != nil {
+			logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+		} else {
+			logger.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+			ruleResp := ruleResponse(*rule, ruleType, "Rule skipped because of PolicyException "+key, response.RuleStatusSkip)
+			ruleResp.Exception = key
+			return ruleResp
+		}
+	}
+	return nil
+}
+
+// podSecurityExclusions returns the pod security controls excluded by the policy exceptions applying to the resource being processed
+func podSecurityExclusions(logger logr.Logger, policyContext *PolicyContext, rule *kyvernov1.Rule) []kyvernov1.PodSecurityStandard {
+	exceptions, err := matchesExceptions(logger, policyContext, rule)
+	if err != nil {
+		logger.Error(err, "failed to find policy exceptions")
+		return nil
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.policy)
+	if err != nil {
+		logger.Error(err, "failed to compute policy key")
+		return nil
+	}
+	var exclusions []kyvernov1.PodSecurityStandard
+	for _, exception := range exceptions {
+		if controls := exception.Spec.PodSecurityExclusions(policyName, rule.Name); len(controls) != 0 {
+			logger.V(3).Info("pod security controls excluded by policy exception", "namespace", exception.GetNamespace(), "name", exception.GetName())
+			exclusions = append(exclusions, controls...)
+		}
+	}
+	return exclusions
+}
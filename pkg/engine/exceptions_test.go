@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_hasPolicyExceptions_Conditions(t *testing.T) {
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"annotations": map[string]interface{}{
+				"security-review-ticket": "SEC-42",
+			},
+		},
+	}}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+	rule := &kyvernov1.Rule{Name: "rule"}
+	newException := func(key string) *kyvernov2alpha1.PolicyException {
+		return &kyvernov2alpha1.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "default"},
+			Spec: kyvernov2alpha1.PolicyExceptionSpec{
+				Match: kyvernov2beta1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+					}},
+				},
+				Exceptions: []kyvernov2alpha1.Exception{{PolicyName: "policy", RuleNames: []string{"rule"}}},
+				Conditions: &kyvernov2beta1.AnyAllConditions{
+					AllConditions: []kyvernov2beta1.Condition{{
+						RawKey:   &apiextv1.JSON{Raw: []byte(`"{{ request.object.metadata.annotations.\"` + key + `\" || '' }}"`)},
+						Operator: kyvernov2beta1.ConditionOperators["NotEquals"],
+						RawValue: &apiextv1.JSON{Raw: []byte(`""`)},
+					}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		exception *kyvernov2alpha1.PolicyException
+		wantSkip  bool
+	}{{
+		name:      "conditions pass",
+		exception: newException("security-review-ticket"),
+		wantSkip:  true,
+	}, {
+		name:      "conditions fail",
+		exception: newException("other-annotation"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NilError(t, indexer.Add(tt.exception))
+			policyContext := NewPolicyContext().
+				WithPolicy(policy).
+				WithNewResource(pod).
+				WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer))
+			assert.NilError(t, policyContext.JSONContext().AddResource(pod.Object))
+			ruleResp := hasPolicyExceptions(logging.GlobalLogger(), response.Validation, policyContext, rule)
+			if tt.wantSkip {
+				assert.Assert(t, ruleResp != nil)
+				assert.Equal(t, ruleResp.Status, response.RuleStatusSkip)
+				assert.Equal(t, ruleResp.Exception, "default/exception")
+			} else {
+				assert.Assert(t, ruleResp == nil)
+			}
+		})
+	}
+}
+
+var testExceptionContextPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "policy",
+    "annotations": {
+      "pod-policies.kyverno.io/autogen-controllers": "none"
+    }
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "mutate",
+        "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+        "context": [{"name": "ticket", "variable": {"jmesPath": "request.object.metadata.annotations.ticket || ''"}}],
+        "mutate": {"patchStrategicMerge": {"metadata": {"labels": {"mutated": "true"}}}}
+      },
+      {
+        "name": "verify",
+        "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+        "context": [{"name": "ticket", "variable": {"jmesPath": "request.object.metadata.annotations.ticket || ''"}}],
+        "verifyImages": [{"imageReferences": ["ghcr.io/kyverno/*"], "attestors": [{"entries": [{"keys": {"publicKeys": "unused"}}]}]}]
+      },
+      {
+        "name": "generate",
+        "match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+        "context": [{"name": "ticket", "variable": {"jmesPath": "request.object.metadata.annotations.ticket || ''"}}],
+        "generate": {"apiVersion": "v1", "kind": "ConfigMap", "name": "generated", "namespace": "default", "data": {"data": {"key": "value"}}}
+      }
+    ]
+  }
+}`
+
+func newContextExceptionPolicyContext(t *testing.T, annotations map[string]interface{}) *PolicyContext {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(testExceptionContextPolicy), &policy))
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":        "test",
+			"namespace":   "default",
+			"annotations": annotations,
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "test", "image": "ghcr.io/kyverno/test-verify-image:signed"},
+			},
+		},
+	}}
+	// the exception condition references the rule context variable
+	exception := &kyvernov2alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "exception", Namespace: "default"},
+		Spec: kyvernov2alpha1.PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+				}},
+			},
+			Exceptions: []kyvernov2alpha1.Exception{{PolicyName: "policy", RuleNames: []string{"mutate", "verify", "generate"}}},
+			Conditions: &kyvernov2beta1.AnyAllConditions{
+				AllConditions: []kyvernov2beta1.Condition{{
+					RawKey:   &apiextv1.JSON{Raw: []byte(`"{{ ticket }}"`)},
+					Operator: kyvernov2beta1.ConditionOperators["NotEquals"],
+					RawValue: &apiextv1.JSON{Raw: []byte(`""`)},
+				}},
+			},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, indexer.Add(exception))
+	policyContext := NewPolicyContext().
+		WithPolicy(&policy).
+		WithNewResource(pod).
+		WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer))
+	assert.NilError(t, policyContext.JSONContext().AddResource(pod.Object))
+	assert.NilError(t, policyContext.JSONContext().AddImageInfos(&pod))
+	return policyContext
+}
+
+func Test_PolicyExceptions_RuleContext(t *testing.T) {
+	excepted := map[string]interface{}{"ticket": "SEC-42"}
+	tests := []struct {
+		name     string
+		ruleType response.RuleType
+		apply    func(*PolicyContext) *response.EngineResponse
+	}{{
+		name:     "mutate",
+		ruleType: response.Mutation,
+		apply: func(policyContext *PolicyContext) *response.EngineResponse {
+			return Mutate(context.TODO(), registryclient.NewOrDie(), policyContext)
+		},
+	}, {
+		name:     "verify",
+		ruleType: response.ImageVerify,
+		apply: func(policyContext *PolicyContext) *response.EngineResponse {
+			resp, _ := VerifyAndPatchImages(context.TODO(), registryclient.NewOrDie(), policyContext)
+			return resp
+		},
+	}, {
+		name:     "generate",
+		ruleType: response.Generation,
+		apply: func(policyContext *PolicyContext) *response.EngineResponse {
+			return ApplyBackgroundChecks(registryclient.NewOrDie(), policyContext)
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := tt.apply(newContextExceptionPolicyContext(t, excepted))
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			ruleResp := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, ruleResp.Name, tt.name)
+			assert.Equal(t, ruleResp.Type, tt.ruleType)
+			assert.Equal(t, ruleResp.Status, response.RuleStatusSkip)
+			assert.Equal(t, ruleResp.Exception, "default/exception")
+		})
+	}
+	// without the annotation the exception doesn't apply, image verification is left out as it needs a registry
+	for _, tt := range tests {
+		if tt.ruleType == response.ImageVerify {
+			continue
+		}
+		t.Run(tt.name+" not excepted", func(t *testing.T) {
+			resp := tt.apply(newContextExceptionPolicyContext(t, map[string]interface{}{}))
+			assert.Equal(t, len(resp.PolicyResponse.Rules), 1)
+			ruleResp := resp.PolicyResponse.Rules[0]
+			assert.Equal(t, ruleResp.Status, response.RuleStatusPass)
+			assert.Equal(t, ruleResp.Exception, "")
+		})
+	}
+}
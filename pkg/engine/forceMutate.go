@@ -76,7 +76,7 @@ func applyForEachMutate(name string, foreach []kyvernov1.ForEachMutation, resour
 }
 
 func applyPatches(name string, mergePatch apiextensions.JSON, jsonPatch string, resource unstructured.Unstructured, ctx context.Interface, logger logr.Logger) (unstructured.Unstructured, error) {
-	patcher := mutate.NewPatcher(name, mergePatch, jsonPatch, resource, ctx, logger)
+	patcher := mutate.NewPatcher(name, mergePatch, jsonPatch, resource, ctx, nil, logger)
 	resp, mutatedResource := patcher.Patch()
 	if resp.Status != response.RuleStatusPass {
 		return mutatedResource, fmt.Errorf("mutate status %q: %s", resp.Status.String(), resp.Message)
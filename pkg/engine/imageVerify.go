@@ -132,6 +132,12 @@ func VerifyAndPatchImages(
 					return
 				}
 
+				// check if there is a corresponding policy exception
+				if ruleResp := hasPolicyExceptions(logger, response.ImageVerify, policyContext, rule); ruleResp != nil {
+					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
+					return
+				}
+
 				ruleCopy, err := substituteVariables(rule, policyContext.jsonContext, logger)
 				if err != nil {
 					appendResponse(resp, rule, fmt.Sprintf("failed to substitute variables: %s", err.Error()), response.RuleStatusError)
@@ -38,6 +38,11 @@ func processImageValidationRule(ctx context.Context, log logr.Logger, rclient re
 		return ruleError(rule, response.Validation, "failed to load context", err)
 	}
 
+	// check if there is a corresponding policy exception
+	if ruleResp := hasPolicyExceptions(log, response.Validation, enginectx, rule); ruleResp != nil {
+		return ruleResp
+	}
+
 	preconditionsPassed, err := checkPreconditions(log, enginectx, rule.RawAnyAllConditions)
 	if err != nil {
 		return ruleError(rule, response.Validation, "failed to evaluate preconditions", err)
@@ -19,8 +19,11 @@ func LoadContext(ctx context.Context, logger logr.Logger, rclient registryclient
 		return nil
 	}
 
-	policyName := enginectx.policy.GetName()
 	if store.GetMock() {
+		var policyName string
+		if enginectx.policy != nil {
+			policyName = enginectx.policy.GetName()
+		}
 		rule := store.GetPolicyRuleFromContext(policyName, ruleName)
 		if rule != nil && len(rule.Values) > 0 {
 			variables := rule.Values
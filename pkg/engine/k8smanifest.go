@@ -39,6 +39,10 @@ func processYAMLValidationRule(log logr.Logger, ctx *PolicyContext, rule *kyvern
 	if isDeleteRequest(ctx) {
 		return nil
 	}
+	// check if there is a corresponding policy exception
+	if ruleResp := hasPolicyExceptions(log, response.Validation, ctx, rule); ruleResp != nil {
+		return ruleResp
+	}
 	ruleResp := handleVerifyManifest(ctx, rule, log)
 	return ruleResp
 }
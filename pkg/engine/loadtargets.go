@@ -13,6 +13,7 @@ import (
 	"go.uber.org/multierr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 )
 
 // resourceInfo contains the Unstructured resource, and if the resource is a subresource, it contains its name and its
@@ -23,7 +24,7 @@ type resourceInfo struct {
 	parentResourceGVR metav1.GroupVersionResource
 }
 
-func loadTargets(targets []kyvernov1.ResourceSpec, ctx *PolicyContext, logger logr.Logger) ([]resourceInfo, error) {
+func loadTargets(targets []kyvernov1.TargetResourceSpec, ctx *PolicyContext, logger logr.Logger) ([]resourceInfo, error) {
 	var targetObjects []resourceInfo
 	var errors []error
 
@@ -40,42 +41,83 @@ func loadTargets(targets []kyvernov1.ResourceSpec, ctx *PolicyContext, logger lo
 			continue
 		}
 
+		objs, err = filterTargets(spec, objs, ctx, logger)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("failed to evaluate target[%d] preconditions: %v", i, err))
+			continue
+		}
+
 		targetObjects = append(targetObjects, objs...)
 	}
 
 	return targetObjects, multierr.Combine(errors...)
 }
 
-func resolveSpec(i int, target kyvernov1.ResourceSpec, ctx *PolicyContext, logger logr.Logger) (kyvernov1.ResourceSpec, error) {
+func resolveSpec(i int, target kyvernov1.TargetResourceSpec, ctx *PolicyContext, logger logr.Logger) (kyvernov1.TargetResourceSpec, error) {
 	kind, err := variables.SubstituteAll(logger, ctx.jsonContext, target.Kind)
 	if err != nil {
-		return kyvernov1.ResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Kind %s: %v", i, target.Kind, err)
+		return kyvernov1.TargetResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Kind %s: %v", i, target.Kind, err)
 	}
 
 	apiversion, err := variables.SubstituteAll(logger, ctx.jsonContext, target.APIVersion)
 	if err != nil {
-		return kyvernov1.ResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].APIVersion %s: %v", i, target.APIVersion, err)
+		return kyvernov1.TargetResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].APIVersion %s: %v", i, target.APIVersion, err)
 	}
 
 	namespace, err := variables.SubstituteAll(logger, ctx.jsonContext, target.Namespace)
 	if err != nil {
-		return kyvernov1.ResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Namespace %s: %v", i, target.Namespace, err)
+		return kyvernov1.TargetResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Namespace %s: %v", i, target.Namespace, err)
 	}
 
 	name, err := variables.SubstituteAll(logger, ctx.jsonContext, target.Name)
 	if err != nil {
-		return kyvernov1.ResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Name %s: %v", i, target.Name, err)
+		return kyvernov1.TargetResourceSpec{}, fmt.Errorf("failed to substitute variables in target[%d].Name %s: %v", i, target.Name, err)
 	}
 
-	return kyvernov1.ResourceSpec{
-		APIVersion: apiversion.(string),
-		Kind:       kind.(string),
-		Namespace:  namespace.(string),
-		Name:       name.(string),
+	return kyvernov1.TargetResourceSpec{
+		ResourceSpec: kyvernov1.ResourceSpec{
+			APIVersion: apiversion.(string),
+			Kind:       kind.(string),
+			Namespace:  namespace.(string),
+			Name:       name.(string),
+		},
+		Selector:            target.Selector,
+		NamespaceSelector:   target.NamespaceSelector,
+		RawAnyAllConditions: target.RawAnyAllConditions,
 	}, nil
 }
 
-func getTargets(target kyvernov1.ResourceSpec, ctx *PolicyContext) ([]resourceInfo, error) {
+// filterTargets returns the targets satisfying the target preconditions, the target resource
+// being available in the `target` variable while the preconditions are evaluated
+func filterTargets(target kyvernov1.TargetResourceSpec, objs []resourceInfo, ctx *PolicyContext, logger logr.Logger) ([]resourceInfo, error) {
+	if target.RawAnyAllConditions == nil {
+		return objs, nil
+	}
+	var filtered []resourceInfo
+	for _, obj := range objs {
+		pass, err := checkTargetPreconditions(target, obj, ctx, logger)
+		if err != nil {
+			return nil, err
+		}
+		if pass {
+			filtered = append(filtered, obj)
+		} else {
+			logger.V(4).Info("target preconditions not met", "namespace", obj.unstructured.GetNamespace(), "name", obj.unstructured.GetName())
+		}
+	}
+	return filtered, nil
+}
+
+func checkTargetPreconditions(target kyvernov1.TargetResourceSpec, obj resourceInfo, ctx *PolicyContext, logger logr.Logger) (bool, error) {
+	ctx.jsonContext.Checkpoint()
+	defer ctx.jsonContext.Restore()
+	if err := ctx.jsonContext.AddTargetResource(obj.unstructured.Object); err != nil {
+		return false, err
+	}
+	return checkPreconditions(logger, ctx, target.GetAnyAllConditions())
+}
+
+func getTargets(target kyvernov1.TargetResourceSpec, ctx *PolicyContext) ([]resourceInfo, error) {
 	var targetObjects []resourceInfo
 	namespace := target.Namespace
 	name := target.Name
@@ -90,8 +132,20 @@ func getTargets(target kyvernov1.ResourceSpec, ctx *PolicyContext) ([]resourceIn
 		return nil, err
 	}
 
+	var namespaces []string
+	if apiResource.Namespaced {
+		namespaces, err = getTargetNamespaces(namespace, target.NamespaceSelector, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if namespace != "" && name != "" &&
 		!wildcard.ContainsWildcard(namespace) && !wildcard.ContainsWildcard(name) {
+		if apiResource.Namespaced && len(namespaces) == 0 {
+			// the namespace does not match the namespace selector
+			return nil, nil
+		}
 		// If the target resource is a subresource
 		var obj *unstructured.Unstructured
 		var parentResourceGVR metav1.GroupVersionResource
@@ -114,6 +168,13 @@ func getTargets(target kyvernov1.ResourceSpec, ctx *PolicyContext) ([]resourceIn
 		if err != nil {
 			return nil, fmt.Errorf("failed to get target %s/%s %s/%s : %v", target.APIVersion, target.Kind, namespace, name, err)
 		}
+		matched, err := matchesSelector(target.Selector, obj.GetLabels())
+		if err != nil {
+			return nil, fmt.Errorf("invalid target selector: %v", err)
+		}
+		if !matched {
+			return nil, nil
+		}
 
 		return []resourceInfo{{unstructured: *obj, subresource: subresourceName, parentResourceGVR: parentResourceGVR}}, nil
 	}
@@ -123,17 +184,10 @@ func getTargets(target kyvernov1.ResourceSpec, ctx *PolicyContext) ([]resourceIn
 			Group:   parentAPIResource.Group,
 			Version: parentAPIResource.Version,
 		}.String()
-		objList, err := ctx.client.ListResource(context.TODO(), apiVersion, parentAPIResource.Kind, "", nil)
+		parentObjects, err := listTargets(apiVersion, parentAPIResource.Kind, namespace, name, namespaces, target.Selector, ctx)
 		if err != nil {
 			return nil, err
 		}
-		var parentObjects []unstructured.Unstructured
-		for i := range objList.Items {
-			obj := objList.Items[i].DeepCopy()
-			if match(namespace, name, obj.GetNamespace(), obj.GetName()) {
-				parentObjects = append(parentObjects, *obj)
-			}
-		}
 
 		for i := range parentObjects {
 			parentObj := parentObjects[i]
@@ -151,20 +205,83 @@ func getTargets(target kyvernov1.ResourceSpec, ctx *PolicyContext) ([]resourceIn
 		}
 	} else {
 		// list all targets if wildcard is specified
-		objList, err := ctx.client.ListResource(context.TODO(), target.APIVersion, target.Kind, "", nil)
+		objs, err := listTargets(target.APIVersion, target.Kind, namespace, name, namespaces, target.Selector, ctx)
 		if err != nil {
 			return nil, err
 		}
 
+		for i := range objs {
+			targetObjects = append(targetObjects, resourceInfo{unstructured: objs[i]})
+		}
+	}
+
+	return targetObjects, nil
+}
+
+// getTargetNamespaces returns the namespaces the targets are listed from, nil meaning all namespaces.
+// When a namespace selector is set only the namespaces matching the selector and the namespace pattern are returned.
+func getTargetNamespaces(namespace string, namespaceSelector *metav1.LabelSelector, ctx *PolicyContext) ([]string, error) {
+	if namespaceSelector == nil {
+		if namespace != "" && !wildcard.ContainsWildcard(namespace) {
+			return []string{namespace}, nil
+		}
+		return nil, nil
+	}
+	nsList, err := ctx.client.ListResource(context.TODO(), "v1", "Namespace", "", namespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for target namespace selector: %v", err)
+	}
+	namespaces := []string{}
+	for _, ns := range nsList.Items {
+		if namespace == "" || wildcard.Match(namespace, ns.GetName()) {
+			namespaces = append(namespaces, ns.GetName())
+		}
+	}
+	return namespaces, nil
+}
+
+// listTargets lists the resources matching the name pattern and label selector, in the given namespaces
+// or in all namespaces if namespaces is nil. The label selector is applied by the API server.
+func listTargets(apiVersion, kind, namespacePattern, namePattern string, namespaces []string, selector *metav1.LabelSelector, ctx *PolicyContext) ([]unstructured.Unstructured, error) {
+	var objs []unstructured.Unstructured
+	add := func(namespace string) error {
+		objList, err := ctx.client.ListResource(context.TODO(), apiVersion, kind, namespace, selector)
+		if err != nil {
+			return err
+		}
 		for i := range objList.Items {
 			obj := objList.Items[i].DeepCopy()
-			if match(namespace, name, obj.GetNamespace(), obj.GetName()) {
-				targetObjects = append(targetObjects, resourceInfo{unstructured: *obj})
+			matched := match(namespacePattern, namePattern, obj.GetNamespace(), obj.GetName())
+			if namespaces != nil {
+				// namespaces are already filtered
+				matched = match("", namePattern, "", obj.GetName())
+			}
+			if matched {
+				objs = append(objs, *obj)
 			}
 		}
+		return nil
 	}
+	if namespaces == nil {
+		return objs, add("")
+	}
+	for _, namespace := range namespaces {
+		if err := add(namespace); err != nil {
+			return nil, err
+		}
+	}
+	return objs, nil
+}
 
-	return targetObjects, nil
+func matchesSelector(selector *metav1.LabelSelector, labels map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(k8slabels.Set(labels)), nil
 }
 
 func match(namespacePattern, namePattern, namespace, name string) bool {
@@ -4,7 +4,16 @@ import (
 	"fmt"
 	"testing"
 
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	client "github.com/kyverno/kyverno/pkg/clients/dclient"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_match(t *testing.T) {
@@ -215,3 +224,120 @@ func Test_match(t *testing.T) {
 		assert.Equal(t, test.expectedResult, res, fmt.Sprintf("test %s failed", test.testName))
 	}
 }
+
+func newTargetObject(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func newTargetsPolicyContext(t *testing.T) *PolicyContext {
+	objects := []runtime.Object{
+		newTargetObject("v1", "Namespace", "", "prod", map[string]string{"env": "prod"}),
+		newTargetObject("v1", "Namespace", "", "prod-eu", map[string]string{"env": "prod"}),
+		newTargetObject("v1", "Namespace", "", "dev", map[string]string{"env": "dev"}),
+		newTargetObject("apps/v1", "Deployment", "prod", "foo-api", map[string]string{"app.kubernetes.io/part-of": "foo"}),
+		newTargetObject("apps/v1", "Deployment", "prod", "bar-api", map[string]string{"app.kubernetes.io/part-of": "bar"}),
+		newTargetObject("apps/v1", "Deployment", "prod-eu", "foo-web", map[string]string{"app.kubernetes.io/part-of": "foo"}),
+		newTargetObject("apps/v1", "Deployment", "dev", "foo-api", map[string]string{"app.kubernetes.io/part-of": "foo"}),
+	}
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}:                 "NamespaceList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+	}
+	dclient, err := client.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NoError(t, err)
+	dclient.SetDiscovery(client.NewFakeDiscoveryClient(nil))
+	return &PolicyContext{
+		client:      dclient,
+		policy:      &kyverno.ClusterPolicy{},
+		jsonContext: enginecontext.NewContext(),
+	}
+}
+
+func targetNames(objs []unstructured.Unstructured) []string {
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetNamespace()+"/"+obj.GetName())
+	}
+	return names
+}
+
+func Test_listTargets(t *testing.T) {
+	testCases := []struct {
+		name              string
+		namespace         string
+		targetName        string
+		selector          *metav1.LabelSelector
+		namespaceSelector *metav1.LabelSelector
+		expected          []string
+	}{{
+		name:     "label selector",
+		selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/part-of": "foo"}},
+		expected: []string{"dev/foo-api", "prod/foo-api", "prod-eu/foo-web"},
+	}, {
+		name:              "label and namespace selectors",
+		selector:          &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/part-of": "foo"}},
+		namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		expected:          []string{"prod/foo-api", "prod-eu/foo-web"},
+	}, {
+		name:              "namespace selector and namespace pattern",
+		namespace:         "prod-*",
+		namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		expected:          []string{"prod-eu/foo-web"},
+	}, {
+		name:              "namespace selector and name pattern",
+		targetName:        "*-api",
+		namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		expected:          []string{"prod/bar-api", "prod/foo-api"},
+	}, {
+		name:              "namespace not selected",
+		namespace:         "dev",
+		namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newTargetsPolicyContext(t)
+			namespaces, err := getTargetNamespaces(tc.namespace, tc.namespaceSelector, ctx)
+			assert.NoError(t, err)
+			if tc.namespaceSelector != nil && len(namespaces) == 0 {
+				assert.Empty(t, tc.expected)
+				return
+			}
+			objs, err := listTargets("apps/v1", "Deployment", tc.namespace, tc.targetName, namespaces, tc.selector, ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, targetNames(objs))
+		})
+	}
+}
+
+func Test_filterTargets(t *testing.T) {
+	ctx := newTargetsPolicyContext(t)
+	objs := []resourceInfo{
+		{unstructured: *newTargetObject("apps/v1", "Deployment", "prod", "foo-api", map[string]string{"tier": "api"})},
+		{unstructured: *newTargetObject("apps/v1", "Deployment", "prod", "foo-web", map[string]string{"tier": "web"})},
+	}
+	target := kyverno.TargetResourceSpec{
+		RawAnyAllConditions: &apiextv1.JSON{Raw: []byte(`{"all":[{"key":"{{ target.metadata.labels.tier }}","operator":"Equals","value":"api"}]}`)},
+	}
+	filtered, err := filterTargets(target, objs, ctx, logging.GlobalLogger())
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "foo-api", filtered[0].unstructured.GetName())
+}
+
+func Test_matchesSelector(t *testing.T) {
+	matched, err := matchesSelector(nil, map[string]string{"app": "foo"})
+	assert.NoError(t, err)
+	assert.True(t, matched)
+	matched, err = matchesSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}}, map[string]string{"app": "bar"})
+	assert.NoError(t, err)
+	assert.False(t, matched)
+	// an invalid selector is reported instead of never matching
+	_, err = matchesSelector(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}}}, nil)
+	assert.Error(t, err)
+}
@@ -50,7 +50,7 @@ const endpointsDocument string = `{
 }`
 
 func applyPatches(rule *types.Rule, resource unstructured.Unstructured) (*response.RuleResponse, unstructured.Unstructured) {
-	mutateResp := Mutate(rule, context.NewContext(), resource, logging.GlobalLogger())
+	mutateResp := Mutate(rule, context.NewContext(), resource, nil, logging.GlobalLogger())
 
 	if mutateResp.Status != response.RuleStatusPass {
 		return &response.RuleResponse{
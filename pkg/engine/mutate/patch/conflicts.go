@@ -0,0 +1,150 @@
+package patch
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
+)
+
+// PatchWriter identifies the policy rule that wrote a patch
+type PatchWriter struct {
+	PolicyNamespace string
+	PolicyName      string
+	RuleName        string
+}
+
+func (w PatchWriter) String() string {
+	if w.PolicyNamespace != "" {
+		return fmt.Sprintf("%s/%s/%s", w.PolicyNamespace, w.PolicyName, w.RuleName)
+	}
+	return fmt.Sprintf("%s/%s", w.PolicyName, w.RuleName)
+}
+
+// Conflict describes a path written by a rule and overwritten by a later rule with a different value
+type Conflict struct {
+	Path        string
+	Overwritten PatchWriter
+	Writer      PatchWriter
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("path %s set by policy %s was overwritten by policy %s", c.Path, c.Overwritten, c.Writer)
+}
+
+type trackedWrite struct {
+	writer PatchWriter
+	value  interface{}
+}
+
+// ConflictTracker records which policy rule last wrote each path of a resource
+// and reports when a later rule overwrites a value set by an earlier rule.
+// Array insertions are not considered overwrites.
+type ConflictTracker struct {
+	writes map[string]trackedWrite
+}
+
+func NewConflictTracker() *ConflictTracker {
+	return &ConflictTracker{
+		writes: map[string]trackedWrite{},
+	}
+}
+
+// Track records the patches written by a rule and returns the conflicts they introduce
+func (t *ConflictTracker) Track(writer PatchWriter, patches [][]byte) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, patch := range patches {
+		operation, err := jsonutils.UnmarshalPatchOperation(patch)
+		if err != nil {
+			return conflicts, err
+		}
+		conflicts = append(conflicts, t.track(writer, operation)...)
+	}
+	return conflicts, nil
+}
+
+func (t *ConflictTracker) track(writer PatchWriter, operation *jsonutils.PatchOperation) []Conflict {
+	switch operation.Op {
+	case "add", "replace", "remove":
+	default:
+		return nil
+	}
+	if operation.Op == "add" && isArrayInsertion(operation.Path) {
+		return nil
+	}
+	var conflicts []Conflict
+	for path, write := range t.writes {
+		if relative, ok := relativePath(operation.Path, path); ok {
+			// the operation overwrites the tracked path or one of its ancestors
+			if write.writer != writer {
+				value, found := lookup(operation.Value, relative)
+				if operation.Op == "remove" || !found || !reflect.DeepEqual(value, write.value) {
+					conflicts = append(conflicts, Conflict{Path: path, Overwritten: write.writer, Writer: writer})
+				}
+			}
+			delete(t.writes, path)
+		} else if relative, ok := relativePath(path, operation.Path); ok {
+			// the operation modifies a value nested in the tracked path
+			if write.writer != writer {
+				value, found := lookup(write.value, relative)
+				if found && (operation.Op == "remove" || !reflect.DeepEqual(value, operation.Value)) {
+					conflicts = append(conflicts, Conflict{Path: operation.Path, Overwritten: write.writer, Writer: writer})
+				}
+			}
+		}
+	}
+	if operation.Op != "remove" {
+		t.writes[operation.Path] = trackedWrite{writer: writer, value: operation.Value}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Path < conflicts[j].Path })
+	return conflicts
+}
+
+// relativePath returns the pointer of path relative to parent if parent is path or one of its ancestors
+func relativePath(parent, path string) (string, bool) {
+	if parent == path {
+		return "", true
+	}
+	if strings.HasPrefix(path, parent+"/") {
+		return strings.TrimPrefix(path, parent), true
+	}
+	return "", false
+}
+
+func isArrayInsertion(path string) bool {
+	last := path[strings.LastIndex(path, "/")+1:]
+	if last == "-" {
+		return true
+	}
+	_, err := strconv.Atoi(last)
+	return err == nil
+}
+
+func lookup(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+	for _, token := range jsonpointer.Parse(path) {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			v, ok := typed[token]
+			if !ok {
+				return nil, false
+			}
+			value = v
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(typed) {
+				return nil, false
+			}
+			value = typed[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
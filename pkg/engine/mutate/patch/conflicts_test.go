@@ -0,0 +1,77 @@
+package patch
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_ConflictTracker(t *testing.T) {
+	first := PatchWriter{PolicyName: "first", RuleName: "rule"}
+	second := PatchWriter{PolicyNamespace: "test", PolicyName: "second", RuleName: "rule"}
+	testCases := []struct {
+		name      string
+		first     []string
+		second    []string
+		conflicts []Conflict
+	}{{
+		name:   "different paths",
+		first:  []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second: []string{`{"op":"add","path":"/metadata/labels/bar","value":"b"}`},
+	}, {
+		name:   "same path same value",
+		first:  []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second: []string{`{"op":"replace","path":"/metadata/labels/foo","value":"a"}`},
+	}, {
+		name:      "same path different value",
+		first:     []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second:    []string{`{"op":"replace","path":"/metadata/labels/foo","value":"b"}`},
+		conflicts: []Conflict{{Path: "/metadata/labels/foo", Overwritten: first, Writer: second}},
+	}, {
+		name:      "remove",
+		first:     []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second:    []string{`{"op":"remove","path":"/metadata/labels/foo"}`},
+		conflicts: []Conflict{{Path: "/metadata/labels/foo", Overwritten: first, Writer: second}},
+	}, {
+		name:      "ancestor overwritten",
+		first:     []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second:    []string{`{"op":"replace","path":"/metadata/labels","value":{"bar":"b"}}`},
+		conflicts: []Conflict{{Path: "/metadata/labels/foo", Overwritten: first, Writer: second}},
+	}, {
+		name:   "ancestor keeps value",
+		first:  []string{`{"op":"add","path":"/metadata/labels/foo","value":"a"}`},
+		second: []string{`{"op":"replace","path":"/metadata/labels","value":{"foo":"a","bar":"b"}}`},
+	}, {
+		name:      "nested value overwritten",
+		first:     []string{`{"op":"add","path":"/metadata/labels","value":{"foo":"a"}}`},
+		second:    []string{`{"op":"replace","path":"/metadata/labels/foo","value":"b"}`},
+		conflicts: []Conflict{{Path: "/metadata/labels/foo", Overwritten: first, Writer: second}},
+	}, {
+		name:   "nested value added",
+		first:  []string{`{"op":"add","path":"/metadata/labels","value":{"foo":"a"}}`},
+		second: []string{`{"op":"add","path":"/metadata/labels/bar","value":"b"}`},
+	}, {
+		name:   "array insertion",
+		first:  []string{`{"op":"add","path":"/spec/containers/0","value":{"name":"a"}}`},
+		second: []string{`{"op":"add","path":"/spec/containers/0","value":{"name":"b"}}`},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := NewConflictTracker()
+			conflicts, err := tracker.Track(first, toPatches(tc.first))
+			assert.NilError(t, err)
+			assert.Equal(t, len(conflicts), 0)
+			conflicts, err = tracker.Track(second, toPatches(tc.second))
+			assert.NilError(t, err)
+			assert.DeepEqual(t, conflicts, tc.conflicts)
+		})
+	}
+}
+
+func toPatches(patches []string) [][]byte {
+	var out [][]byte
+	for _, patch := range patches {
+		out = append(out, []byte(patch))
+	}
+	return out
+}
@@ -19,21 +19,23 @@ type patchStrategicMergeHandler struct {
 	patch           apiextensions.JSON
 	patchedResource unstructured.Unstructured
 	evalCtx         context.EvalInterface
+	schemas         SchemaRegistry
 	logger          logr.Logger
 }
 
-func NewPatchStrategicMerge(ruleName string, patch apiextensions.JSON, patchedResource unstructured.Unstructured, context context.EvalInterface, logger logr.Logger) Patcher {
+func NewPatchStrategicMerge(ruleName string, patch apiextensions.JSON, patchedResource unstructured.Unstructured, context context.EvalInterface, schemas SchemaRegistry, logger logr.Logger) Patcher {
 	return patchStrategicMergeHandler{
 		ruleName:        ruleName,
 		patch:           patch,
 		patchedResource: patchedResource,
 		evalCtx:         context,
+		schemas:         schemas,
 		logger:          logger,
 	}
 }
 
 func (h patchStrategicMergeHandler) Patch() (response.RuleResponse, unstructured.Unstructured) {
-	return ProcessStrategicMergePatch(h.ruleName, h.patch, h.patchedResource, h.logger)
+	return ProcessStrategicMergePatch(h.ruleName, h.patch, h.patchedResource, h.schemas, h.logger)
 }
 
 // patchesJSON6902Handler
@@ -11,7 +11,7 @@ import (
 )
 
 func Test_GeneratePatches(t *testing.T) {
-	out, err := strategicMergePatch(logging.GlobalLogger(), string(baseBytes), string(overlayBytes))
+	out, err := strategicMergePatch(logging.GlobalLogger(), string(baseBytes), string(overlayBytes), nil)
 	assert.NilError(t, err)
 
 	expectedPatches := map[string]bool{
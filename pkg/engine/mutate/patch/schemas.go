@@ -0,0 +1,158 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/kustomize/kyaml/yaml/merge2"
+	"sigs.k8s.io/kustomize/kyaml/yaml/walk"
+)
+
+const (
+	listTypeExtension      = "x-kubernetes-list-type"
+	listMapKeysExtension   = "x-kubernetes-list-map-keys"
+	patchStrategyExtension = "x-kubernetes-patch-strategy"
+	patchMergeKeyExtension = "x-kubernetes-patch-merge-key"
+)
+
+// SchemaRegistry provides the merge schemas of custom resources
+type SchemaRegistry interface {
+	// Get returns the merge schema of a custom resource, or nil if it is not known
+	Get(apiVersion, kind string) *spec.Schema
+}
+
+// SchemaSet holds merge schemas indexed by apiVersion/kind
+type SchemaSet map[string]*spec.Schema
+
+// Add parses the openAPIV3Schema of a custom resource version so that strategic merge patches
+// merge its lists of maps by key, as declared by x-kubernetes-list-type and
+// x-kubernetes-list-map-keys, instead of replacing them.
+func (s SchemaSet) Add(apiVersion, kind string, openAPIV3Schema interface{}) error {
+	raw, err := json.Marshal(openAPIV3Schema)
+	if err != nil {
+		return err
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return err
+	}
+	addPatchStrategies(&schema)
+	s[crdSchemaKey(apiVersion, kind)] = &schema
+	return nil
+}
+
+func (s SchemaSet) Get(apiVersion, kind string) *spec.Schema {
+	return s[crdSchemaKey(apiVersion, kind)]
+}
+
+// Schemas is a SchemaRegistry whose schemas are replaced as a whole, lookups never
+// observe a partially updated set
+type Schemas struct {
+	lock    sync.RWMutex
+	schemas SchemaSet
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{schemas: SchemaSet{}}
+}
+
+func (s *Schemas) Get(apiVersion, kind string) *spec.Schema {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.schemas.Get(apiVersion, kind)
+}
+
+// Replace swaps the registered schemas with the given set, the set must not be modified afterwards
+func (s *Schemas) Replace(schemas SchemaSet) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.schemas = schemas
+}
+
+func crdSchemaKey(apiVersion, kind string) string {
+	return fmt.Sprintf("%s/%s", apiVersion, kind)
+}
+
+// addPatchStrategies translates the structural schema list types into the patch strategy
+// extensions used by the kyaml merge, which only knows about built-in patch metadata
+func addPatchStrategies(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+	if _, found := schema.Extensions.GetString(patchStrategyExtension); !found {
+		listType, _ := schema.Extensions.GetString(listTypeExtension)
+		switch listType {
+		case "map":
+			// the walker merges by all the keys of x-kubernetes-list-map-keys, the merge key
+			// extension is only set for single keys as it can't hold more
+			if keys, found := schema.Extensions.GetStringSlice(listMapKeysExtension); found && len(keys) > 0 {
+				schema.Extensions.Add(patchStrategyExtension, "merge")
+				if len(keys) == 1 {
+					schema.Extensions.Add(patchMergeKeyExtension, keys[0])
+				}
+			}
+		case "set":
+			schema.Extensions.Add(patchStrategyExtension, "merge")
+		}
+	}
+	for name, property := range schema.Properties {
+		addPatchStrategies(&property)
+		schema.Properties[name] = property
+	}
+	if schema.Items != nil {
+		addPatchStrategies(schema.Items.Schema)
+	}
+	if schema.AdditionalProperties != nil {
+		addPatchStrategies(schema.AdditionalProperties.Schema)
+	}
+}
+
+func crdSchemaFor(schemas SchemaRegistry, node *yaml.RNode) *openapi.ResourceSchema {
+	if schemas == nil {
+		return nil
+	}
+	meta, err := node.GetMeta()
+	if err != nil || meta.APIVersion == "" || meta.Kind == "" {
+		return nil
+	}
+	schema := schemas.Get(meta.APIVersion, meta.Kind)
+	if schema == nil {
+		return nil
+	}
+	return &openapi.ResourceSchema{Schema: schema}
+}
+
+// mergeFilter does a strategic merge patch like patchstrategicmerge.Filter,
+// using the registered schema when the resource is a custom resource
+type mergeFilter struct {
+	Patch   *yaml.RNode
+	Schemas SchemaRegistry
+}
+
+var _ kio.Filter = mergeFilter{}
+
+func (f mergeFilter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	var result []*yaml.RNode
+	for i := range nodes {
+		r, err := walk.Walker{
+			Sources: []*yaml.RNode{nodes[i], f.Patch},
+			Visitor: merge2.Merger{},
+			Schema:  crdSchemaFor(f.Schemas, nodes[i]),
+			MergeOptions: yaml.MergeOptions{
+				ListIncreaseDirection: yaml.MergeOptionsListPrepend,
+			},
+		}.Walk()
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	assertnew "github.com/stretchr/testify/assert"
+	"gotest.tools/assert"
+)
+
+func Test_StrategicMergePatchWithCRDSchema(t *testing.T) {
+	var schema interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`{
+  "type": "object",
+  "properties": {
+    "spec": {
+      "type": "object",
+      "properties": {
+        "listeners": {
+          "type": "array",
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-list-map-keys": ["name"],
+          "items": {
+            "type": "object",
+            "properties": {
+              "name": {"type": "string"},
+              "port": {"type": "integer"}
+            }
+          }
+        },
+        "ports": {
+          "type": "array",
+          "x-kubernetes-list-type": "map",
+          "x-kubernetes-list-map-keys": ["port", "protocol"],
+          "items": {
+            "type": "object",
+            "properties": {
+              "port": {"type": "integer"},
+              "protocol": {"type": "string"},
+              "name": {"type": "string"}
+            }
+          }
+        },
+        "hosts": {
+          "type": "array",
+          "x-kubernetes-list-type": "set",
+          "items": {"type": "string"}
+        }
+      }
+    }
+  }
+}`), &schema))
+	set := SchemaSet{}
+	assert.NilError(t, set.Add("example.com/v1", "Gateway", schema))
+	schemas := NewSchemas()
+	schemas.Replace(set)
+
+	base := `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80},{"name":"https","port":443}],"hosts":["a.com"]}}`
+	overlay := `{"spec":{"listeners":[{"name":"https","port":8443},{"name":"grpc","port":9090}],"hosts":["b.com"]}}`
+	expected := `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":8443},{"name":"grpc","port":9090},{"name":"http","port":80}],"hosts":["b.com","a.com"]}}`
+
+	out, err := strategicMergePatch(logging.GlobalLogger(), base, overlay, schemas)
+	assert.NilError(t, err)
+	assertnew.JSONEq(t, expected, string(out))
+
+	// lists with several keys are merged by all of them
+	base = `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"ports":[{"port":53,"protocol":"TCP","name":"dns-tcp"},{"port":53,"protocol":"UDP","name":"dns-udp"}]}}`
+	overlay = `{"spec":{"ports":[{"port":53,"protocol":"UDP","name":"dns"}]}}`
+	out, err = strategicMergePatch(logging.GlobalLogger(), base, overlay, schemas)
+	assert.NilError(t, err)
+	assertnew.JSONEq(t, `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"ports":[{"port":53,"protocol":"TCP","name":"dns-tcp"},{"port":53,"protocol":"UDP","name":"dns"}]}}`, string(out))
+
+	// without a registered schema lists are replaced
+	schemas.Replace(SchemaSet{})
+	base = `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"http","port":80},{"name":"https","port":443}],"hosts":["a.com"]}}`
+	overlay = `{"spec":{"listeners":[{"name":"https","port":8443},{"name":"grpc","port":9090}],"hosts":["b.com"]}}`
+	out, err = strategicMergePatch(logging.GlobalLogger(), base, overlay, schemas)
+	assert.NilError(t, err)
+	assertnew.JSONEq(t, `{"apiVersion":"example.com/v1","kind":"Gateway","metadata":{"name":"gw"},"spec":{"listeners":[{"name":"https","port":8443},{"name":"grpc","port":9090}],"hosts":["b.com"]}}`, string(out))
+}
@@ -9,13 +9,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"sigs.k8s.io/kustomize/api/filters/patchstrategicmerge"
 	filtersutil "sigs.k8s.io/kustomize/kyaml/filtersutil"
 	yaml "sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 // ProcessStrategicMergePatch ...
-func ProcessStrategicMergePatch(ruleName string, overlay interface{}, resource unstructured.Unstructured, log logr.Logger) (resp response.RuleResponse, patchedResource unstructured.Unstructured) {
+func ProcessStrategicMergePatch(ruleName string, overlay interface{}, resource unstructured.Unstructured, schemas SchemaRegistry, log logr.Logger) (resp response.RuleResponse, patchedResource unstructured.Unstructured) {
 	startTime := time.Now()
 	logger := log.WithName("ProcessStrategicMergePatch").WithValues("rule", ruleName)
 	logger.V(4).Info("started applying strategicMerge patch", "startTime", startTime)
@@ -43,7 +42,7 @@ func ProcessStrategicMergePatch(ruleName string, overlay interface{}, resource u
 		resp.Message = fmt.Sprintf("failed to process patchStrategicMerge: %v", err)
 		return resp, resource
 	}
-	patchedBytes, err := strategicMergePatch(logger, string(base), string(overlayBytes))
+	patchedBytes, err := strategicMergePatch(logger, string(base), string(overlayBytes), schemas)
 	if err != nil {
 		log.Error(err, "failed to apply patchStrategicMerge")
 		msg := fmt.Sprintf("failed to apply patchStrategicMerge: %v", err)
@@ -81,7 +80,7 @@ func ProcessStrategicMergePatch(ruleName string, overlay interface{}, resource u
 	return resp, patchedResource
 }
 
-func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, error) {
+func strategicMergePatch(logger logr.Logger, base, overlay string, schemas SchemaRegistry) ([]byte, error) {
 	preprocessedYaml, err := preProcessStrategicMergePatch(logger, overlay, base)
 	if err != nil {
 		_, isConditionError := err.(ConditionError)
@@ -98,8 +97,9 @@ func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, erro
 
 	patchStr, _ := preprocessedYaml.String()
 	logger.V(3).Info("applying strategic merge patch", "patch", patchStr)
-	f := patchstrategicmerge.Filter{
-		Patch: preprocessedYaml,
+	f := mergeFilter{
+		Patch:   preprocessedYaml,
+		Schemas: schemas,
 	}
 
 	baseObj := buffer{Buffer: bytes.NewBufferString(base)}
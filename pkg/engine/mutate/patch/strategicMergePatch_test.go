@@ -163,7 +163,7 @@ func TestMergePatch(t *testing.T) {
 
 	for i, test := range testCases {
 		t.Logf("Running test %d...", i+1)
-		out, err := strategicMergePatch(logging.GlobalLogger(), string(test.rawResource), string(test.rawPolicy))
+		out, err := strategicMergePatch(logging.GlobalLogger(), string(test.rawResource), string(test.rawPolicy), nil)
 		assert.NilError(t, err)
 		assert.DeepEqual(t, toJSON(t, test.expected), toJSON(t, out))
 	}
@@ -247,7 +247,7 @@ func Test_PolicyDeserilize(t *testing.T) {
 	patchString, err := json.Marshal(overlayPatches)
 	assert.NilError(t, err)
 
-	out, err := strategicMergePatch(logging.GlobalLogger(), string(baseBytes), string(patchString))
+	out, err := strategicMergePatch(logging.GlobalLogger(), string(baseBytes), string(patchString), nil)
 	assert.NilError(t, err)
 
 	var ep unstructured.Unstructured
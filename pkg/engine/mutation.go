@@ -90,6 +90,12 @@ func Mutate(ctx context.Context, rclient registryclient.Client, policyContext *P
 					return
 				}
 
+				// check if there is a corresponding policy exception
+				if ruleResp := hasPolicyExceptions(logger, response.Mutation, policyContext, &rule); ruleResp != nil {
+					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
+					return
+				}
+
 				ruleCopy := rule.DeepCopy()
 				var patchedResources []resourceInfo
 				if !policyContext.admissionOperation && rule.IsMutateExisting() {
@@ -185,7 +191,7 @@ func mutateResource(rule *kyvernov1.Rule, ctx *PolicyContext, resource unstructu
 		return mutate.NewResponse(response.RuleStatusSkip, resource, nil, "preconditions not met")
 	}
 
-	return mutate.Mutate(rule, ctx.JSONContext(), resource, logger)
+	return mutate.Mutate(rule, ctx.JSONContext(), resource, ctx.mergeSchemas, logger)
 }
 
 type forEachMutator struct {
@@ -303,7 +309,7 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext.JSONContext(), patchedResource.unstructured, f.log)
+			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext.JSONContext(), patchedResource.unstructured, policyContext.mergeSchemas, f.log)
 		}
 
 		if mutateResp.Status == response.RuleStatusFail || mutateResp.Status == response.RuleStatusError {
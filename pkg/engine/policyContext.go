@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"time"
+
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
@@ -9,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -81,6 +84,9 @@ type PolicyContext struct {
 
 	// peLister list all policy exceptions
 	peLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// mergeSchemas provides the schemas used to merge lists of custom resources in strategic merge patches
+	mergeSchemas patch.SchemaRegistry
 }
 
 // Getters
@@ -118,8 +124,10 @@ func (c *PolicyContext) FindExceptions(rule string) ([]*kyvernov2alpha1.PolicyEx
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to compute policy key")
 	}
+	now := time.Now()
 	for _, polex := range polexs {
-		if polex.Contains(policyName, rule) {
+		// exceptions outside of their validity window are ignored
+		if polex.Contains(policyName, rule) && polex.IsActive(now) {
 			result = append(result, polex)
 		}
 	}
@@ -224,6 +232,12 @@ func (c *PolicyContext) WithExceptions(peLister kyvernov2alpha1listers.PolicyExc
 	return copy
 }
 
+func (c *PolicyContext) WithMergeSchemas(mergeSchemas patch.SchemaRegistry) *PolicyContext {
+	copy := c.Copy()
+	copy.mergeSchemas = mergeSchemas
+	return copy
+}
+
 // Constructors
 func NewPolicyContextWithJsonContext(jsonContext enginectx.Interface) *PolicyContext {
 	return &PolicyContext{
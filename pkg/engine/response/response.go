@@ -110,6 +110,9 @@ type RuleResponse struct {
 	// rule status
 	Status RuleStatus `json:"status"`
 
+	// Exception is the namespace/name of the policy exception that caused the rule to be skipped, if any
+	Exception string `json:"exception,omitempty"`
+
 	// statistics
 	RuleStats `json:",inline"`
 
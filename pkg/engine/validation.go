@@ -11,7 +11,6 @@ import (
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/engine/common"
@@ -24,7 +23,6 @@ import (
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/kyverno/kyverno/pkg/utils/api"
-	matched "github.com/kyverno/kyverno/pkg/utils/match"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
@@ -33,7 +31,6 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/tools/cache"
 )
 
 // Validate applies validation rules from policy on the resource
@@ -135,23 +132,6 @@ func validateResource(ctx context.Context, log logr.Logger, rclient registryclie
 				if !matches(log, rule, enginectx) {
 					return nil
 				}
-				// if matches, check if there is a corresponding policy exception
-				exception, err := matchesException(enginectx, rule)
-				// if we found an exception
-				if err == nil && exception != nil {
-					key, err := cache.MetaNamespaceKeyFunc(exception)
-					// TODO: increase metrics
-					if err != nil {
-						log.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
-					} else {
-						log.V(3).Info("policy rule skipped due to policy exception", "exception", key)
-						return &response.RuleResponse{
-							Name:    rule.Name,
-							Message: "Rule skipped because of PolicyException" + key,
-							Status:  response.RuleStatusSkip,
-						}
-					}
-				}
 				log.V(3).Info("processing validation rule", "matchCount", matchCount, "applyRules", applyRules)
 				enginectx.jsonContext.Reset()
 				if hasValidate && !hasYAMLSignatureVerify {
@@ -258,6 +238,13 @@ func (v *validator) validate(ctx context.Context) *response.RuleResponse {
 		return ruleError(v.rule, response.Validation, "failed to load context", err)
 	}
 
+	// check if there is a corresponding policy exception, foreach validators are covered by their parent rule
+	if v.nesting == 0 {
+		if ruleResp := hasPolicyExceptions(v.log, response.Validation, v.policyContext, v.rule); ruleResp != nil {
+			return ruleResp
+		}
+	}
+
 	preconditionsPassed, err := checkPreconditions(v.log, v.policyContext, v.anyAllConditions)
 	if err != nil {
 		return ruleError(v.rule, response.Validation, "failed to evaluate preconditions", err)
@@ -530,7 +517,8 @@ func (v *validator) validatePodSecurity() *response.RuleResponse {
 		Spec:       *podSpec,
 		ObjectMeta: *metadata,
 	}
-	allowed, pssChecks, err := pss.EvaluatePod(v.podSecurity, pod)
+	exclusions := podSecurityExclusions(v.log, v.policyContext, v.rule)
+	allowed, pssChecks, err := pss.EvaluatePod(v.podSecurity, pod, exclusions...)
 	if err != nil {
 		return ruleError(v.rule, response.Validation, "failed to parse pod security api version", err)
 	}
@@ -775,19 +763,3 @@ func (v *validator) substituteDeny() error {
 	v.deny = i.(*kyvernov1.Deny)
 	return nil
 }
-
-// matchesException checks if an exception applies to the resource being admitted
-func matchesException(policyContext *PolicyContext, rule *kyvernov1.Rule) (*kyvernov2alpha1.PolicyException, error) {
-	candidates, err := policyContext.FindExceptions(rule.Name)
-	if err != nil {
-		return nil, err
-	}
-	for _, candidate := range candidates {
-		err := matched.CheckMatchesResources(policyContext.newResource, candidate.Spec.Match, policyContext.namespaceLabels)
-		// if there's no error it means a match
-		if err == nil {
-			return candidate, nil
-		}
-	}
-	return nil, nil
-}
@@ -1,23 +1,23 @@
-package cleanup
+package variables
 
 import (
 	"testing"
 
 	"github.com/go-logr/logr"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
-	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/logging"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
-func Test_checkCondition(t *testing.T) {
-	ctx := enginecontext.NewContext()
+func Test_CheckCondition(t *testing.T) {
+	ctx := context.NewContext()
 	ctx.AddResource(map[string]interface{}{
 		"name": "dummy",
 	})
 	type args struct {
 		logger    logr.Logger
-		ctx       enginecontext.Interface
+		ctx       context.Interface
 		condition kyvernov2beta1.Condition
 	}
 	tests := []struct {
@@ -44,13 +44,13 @@ func Test_checkCondition(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := checkCondition(tt.args.logger, tt.args.ctx, tt.args.condition)
+			got, err := CheckCondition(tt.args.logger, tt.args.ctx, tt.args.condition)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("checkCondition() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("CheckCondition() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("checkCondition() = %v, want %v", got, tt.want)
+				t.Errorf("CheckCondition() = %v, want %v", got, tt.want)
 			}
 		})
 	}
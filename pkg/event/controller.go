@@ -203,7 +203,7 @@ func (gen *Generator) syncHandler(key Info) error {
 	// if skip/pass, reason will be: NORMAL
 	// else reason will be: WARNING
 	eventType := corev1.EventTypeWarning
-	if key.Reason == PolicyApplied.String() || key.Reason == PolicySkipped.String() {
+	if key.Reason == PolicyApplied.String() || key.Reason == PolicySkipped.String() || key.Reason == PolicyException.String() {
 		eventType = corev1.EventTypeNormal
 	}
 
@@ -125,3 +125,47 @@ func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructur
 
 	return events
 }
+
+func NewMutationConflictEvent(source Source, policyNamespace, policyName string, resource response.ResourceSpec, message string) Info {
+	var bldr strings.Builder
+	defer bldr.Reset()
+
+	if resource.Namespace != "" {
+		fmt.Fprintf(&bldr, "%s %s/%s: %s", resource.Kind, resource.Namespace, resource.Name, message)
+	} else {
+		fmt.Fprintf(&bldr, "%s %s: %s", resource.Kind, resource.Name, message)
+	}
+
+	kind := "ClusterPolicy"
+	if policyNamespace != "" {
+		kind = "Policy"
+	}
+
+	return Info{
+		Kind:      kind,
+		Name:      policyName,
+		Namespace: policyNamespace,
+		Reason:    PolicyConflict.String(),
+		Source:    source,
+		Message:   bldr.String(),
+	}
+}
+
+func NewPolicyExceptionEvents(source Source, engineResponse *response.EngineResponse) []Info {
+	var events []Info
+	resource := engineResponse.GetResourceSpec()
+	for _, ruleResp := range engineResponse.PolicyResponse.Rules {
+		if ruleResp.Exception == "" {
+			continue
+		}
+		events = append(events, Info{
+			Kind:      resource.Kind,
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+			Reason:    PolicyException.String(),
+			Source:    source,
+			Message:   fmt.Sprintf("policy %s/%s skipped due to policy exception %s", engineResponse.Policy.GetName(), ruleResp.Name, ruleResp.Exception),
+		})
+	}
+	return events
+}
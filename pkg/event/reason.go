@@ -8,6 +8,8 @@ const (
 	PolicyApplied
 	PolicyError
 	PolicySkipped
+	PolicyConflict
+	PolicyException
 )
 
 func (r Reason) String() string {
@@ -16,5 +18,7 @@ func (r Reason) String() string {
 		"PolicyApplied",
 		"PolicyError",
 		"PolicySkipped",
+		"PolicyConflict",
+		"PolicyException",
 	}[r]
 }
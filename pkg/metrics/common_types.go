@@ -79,3 +79,22 @@ const (
 	KyvernoClient      ClientType = "kyverno"
 	PolicyReportClient ClientType = "policyreport"
 )
+
+type CleanupResult string
+
+const (
+	CleanupDeleted CleanupResult = "deleted"
+	CleanupFailed  CleanupResult = "failed"
+	CleanupDryRun  CleanupResult = "dry_run"
+)
+
+type BackgroundScanEvent string
+
+const (
+	// BackgroundScanScheduled is recorded when a resource is queued for a periodic rescan of a policy
+	BackgroundScanScheduled BackgroundScanEvent = "scheduled"
+	// BackgroundScanScanned is recorded when a resource has been scanned against a policy
+	BackgroundScanScanned BackgroundScanEvent = "scanned"
+	// BackgroundScanThrottled is recorded when a scan had to wait because of the policy rate limit
+	BackgroundScanThrottled BackgroundScanEvent = "throttled"
+)
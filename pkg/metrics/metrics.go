@@ -35,6 +35,13 @@ type MetricsConfig struct {
 	policyResultsMetric           syncint64.Counter
 	policyExecutionDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	mutationConflictsMetric       syncint64.Counter
+	cleanupExecutionsMetric       syncint64.Counter
+	cleanupResourcesMetric        syncint64.Counter
+	ttlDeletionsMetric            syncint64.Counter
+	policyExceptionsMetric        syncint64.Counter
+	resultTransitionsMetric       syncint64.Counter
+	backgroundScanMetric          syncint64.Counter
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -47,6 +54,13 @@ type MetricsConfigManager interface {
 	RecordPolicyChanges(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, policyChangeType string)
 	RecordPolicyExecutionDuration(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, ruleExecutionLatency float64)
 	RecordClientQueries(ctx context.Context, clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordMutationConflicts(ctx context.Context, policyNamespace string, policyName string, ruleName string, overwrittenPolicyNamespace string, overwrittenPolicyName string, overwrittenRuleName string, resourceKind string, resourceNamespace string)
+	RecordCleanupExecutions(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string)
+	RecordCleanupResources(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string, cleanupResult CleanupResult)
+	RecordTTLDeletions(ctx context.Context, resourceKind string, resourceNamespace string, cleanupResult CleanupResult)
+	RecordPolicyExceptions(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, ruleName string, exceptionNamespace string, exceptionName string, resourceKind string, resourceNamespace string, ruleExecutionCause RuleExecutionCause)
+	RecordPolicyResultTransitions(ctx context.Context, policyNamespace string, policyName string, ruleName string, resourceKind string, resourceNamespace string, fromResult string, toResult string)
+	RecordBackgroundScan(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceNamespace string, backgroundScanEvent BackgroundScanEvent)
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -76,6 +90,41 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 		m.Log.Error(err, "Failed to create instrument, kyverno_client_queries")
 		return err
 	}
+	m.mutationConflictsMetric, err = meter.SyncInt64().Counter("kyverno_mutation_conflicts", instrument.WithDescription("can be used to track the number of times a mutate rule overwrites a value set by another mutate rule during the same admission request"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_mutation_conflicts")
+		return err
+	}
+	m.cleanupExecutionsMetric, err = meter.SyncInt64().Counter("kyverno_cleanup_controller_executions", instrument.WithDescription("can be used to track the number of times cleanup policies are executed"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_cleanup_controller_executions")
+		return err
+	}
+	m.cleanupResourcesMetric, err = meter.SyncInt64().Counter("kyverno_cleanup_controller_resources", instrument.WithDescription("can be used to track the resources matched by cleanup policies, by result (deleted, failed or dry_run)"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_cleanup_controller_resources")
+		return err
+	}
+	m.ttlDeletionsMetric, err = meter.SyncInt64().Counter("kyverno_ttl_controller_deletions", instrument.WithDescription("can be used to track the resources deleted by the cleanup controller because their TTL label expired, by result (deleted or failed)"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_ttl_controller_deletions")
+		return err
+	}
+	m.policyExceptionsMetric, err = meter.SyncInt64().Counter("kyverno_policy_exceptions", instrument.WithDescription("can be used to track the number of times policy exceptions caused rules to be skipped"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_policy_exceptions")
+		return err
+	}
+	m.resultTransitionsMetric, err = meter.SyncInt64().Counter("kyverno_policy_result_transitions", instrument.WithDescription("can be used to track the changes of policy report results, including results appearing (from none) and disappearing (to none)"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_policy_result_transitions")
+		return err
+	}
+	m.backgroundScanMetric, err = meter.SyncInt64().Counter("kyverno_background_scan_resources", instrument.WithDescription("can be used to track the progress of background scans per policy, by event (scheduled, scanned or throttled)"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_background_scan_resources")
+		return err
+	}
 	return nil
 }
 
@@ -230,3 +279,92 @@ func (m *MetricsConfig) RecordClientQueries(ctx context.Context, clientQueryOper
 	}
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordMutationConflicts(ctx context.Context, policyNamespace string, policyName string, ruleName string, overwrittenPolicyNamespace string, overwrittenPolicyName string, overwrittenRuleName string, resourceKind string, resourceNamespace string) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("overwritten_policy_namespace", overwrittenPolicyNamespace),
+		attribute.String("overwritten_policy_name", overwrittenPolicyName),
+		attribute.String("overwritten_rule_name", overwrittenRuleName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+	}
+	m.mutationConflictsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordCleanupExecutions(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+	}
+	m.cleanupExecutionsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordCleanupResources(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, resourceKind string, resourceNamespace string, cleanupResult CleanupResult) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("cleanup_result", string(cleanupResult)),
+	}
+	m.cleanupResourcesMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordTTLDeletions(ctx context.Context, resourceKind string, resourceNamespace string, cleanupResult CleanupResult) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("cleanup_result", string(cleanupResult)),
+	}
+	m.ttlDeletionsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordPolicyExceptions(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string, ruleName string,
+	exceptionNamespace string, exceptionName string, resourceKind string, resourceNamespace string, ruleExecutionCause RuleExecutionCause,
+) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("exception_namespace", exceptionNamespace),
+		attribute.String("exception_name", exceptionName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("rule_execution_cause", string(ruleExecutionCause)),
+	}
+	m.policyExceptionsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordPolicyResultTransitions(ctx context.Context, policyNamespace string, policyName string, ruleName string,
+	resourceKind string, resourceNamespace string, fromResult string, toResult string,
+) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("from_result", fromResult),
+		attribute.String("to_result", toResult),
+	}
+	m.resultTransitionsMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordBackgroundScan(ctx context.Context, policyType PolicyType, policyNamespace string, policyName string,
+	resourceNamespace string, backgroundScanEvent BackgroundScanEvent,
+) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("event", string(backgroundScanEvent)),
+	}
+	m.backgroundScanMetric.Add(ctx, 1, commonLabels...)
+}
@@ -0,0 +1,34 @@
+package mutationconflicts
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/metrics"
+)
+
+func policyNamespace(namespace string) string {
+	if namespace == "" {
+		return "-"
+	}
+	return namespace
+}
+
+func ProcessConflicts(ctx context.Context, m metrics.MetricsConfigManager, resourceKind, resourceNamespace string, conflicts []patch.Conflict) {
+	for _, conflict := range conflicts {
+		namespace := policyNamespace(conflict.Writer.PolicyNamespace)
+		if m.Config().CheckNamespace(namespace) {
+			m.RecordMutationConflicts(
+				ctx,
+				namespace,
+				conflict.Writer.PolicyName,
+				conflict.Writer.RuleName,
+				policyNamespace(conflict.Overwritten.PolicyNamespace),
+				conflict.Overwritten.PolicyName,
+				conflict.Overwritten.RuleName,
+				resourceKind,
+				resourceNamespace,
+			)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/client-go/tools/cache"
 )
 
 func registerPolicyResultsMetric(
@@ -30,6 +31,29 @@ func registerPolicyResultsMetric(
 	}
 }
 
+func registerPolicyExceptionsMetric(
+	ctx context.Context,
+	m metrics.MetricsConfigManager,
+	policyType metrics.PolicyType,
+	policyNamespace, policyName string,
+	ruleName string,
+	exception string,
+	resourceKind, resourceNamespace string,
+	ruleExecutionCause metrics.RuleExecutionCause,
+) error {
+	exceptionNamespace, exceptionName, err := cache.SplitMetaNamespaceKey(exception)
+	if err != nil {
+		return err
+	}
+	if policyType == metrics.Cluster {
+		policyNamespace = "-"
+	}
+	if m.Config().CheckNamespace(policyNamespace) {
+		m.RecordPolicyExceptions(ctx, policyType, policyNamespace, policyName, ruleName, exceptionNamespace, exceptionName, resourceKind, resourceNamespace, ruleExecutionCause)
+	}
+	return nil
+}
+
 // policy - policy related data
 // engineResponse - resource and rule related data
 func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager, policy kyvernov1.PolicyInterface, engineResponse response.EngineResponse, executionCause metrics.RuleExecutionCause, resourceRequestOperation metrics.ResourceRequestOperation) error {
@@ -73,6 +97,11 @@ func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager,
 			ruleType,
 			executionCause,
 		)
+		if rule.Exception != "" {
+			if err := registerPolicyExceptionsMetric(ctx, m, policyType, namespace, name, ruleName, rule.Exception, resourceKind, resourceNamespace, executionCause); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -26,3 +26,23 @@ var crdDefinitionNew struct {
 		} `json:"versions"`
 	} `json:"spec"`
 }
+
+// crdVersionsDefinition represents the schemas of all the versions of a CRD
+type crdVersionsDefinition struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Version    string `json:"version"`
+		Validation struct {
+			OpenAPIV3Schema interface{} `json:"openAPIV3Schema"`
+		} `json:"validation"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Schema struct {
+				OpenAPIV3Schema interface{} `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/logging"
 	cmap "github.com/orcaman/concurrent-map/v2"
 	"github.com/pkg/errors"
@@ -29,6 +30,8 @@ type ValidateInterface interface {
 type Manager interface {
 	ValidateInterface
 	openapicontroller.Manager
+	// MergeSchemas returns the schemas used to merge lists of custom resources in strategic merge patches
+	MergeSchemas() patch.SchemaRegistry
 }
 
 type manager struct {
@@ -48,6 +51,10 @@ type manager struct {
 
 	// kindToAPIVersions stores the Kind and all its available apiVersions, {kind: apiVersions}
 	kindToAPIVersions cmap.ConcurrentMap[string, apiVersions]
+
+	// mergeSchemas holds the merge schemas in use, crdMergeSchemas the ones parsed since the previous sync
+	mergeSchemas    *patch.Schemas
+	crdMergeSchemas patch.SchemaSet
 }
 
 // apiVersions stores all available gvks for a kind, a gvk is "/" separated string
@@ -62,6 +69,8 @@ func NewManager() (*manager, error) {
 		definitions:         cmap.New[*openapiv2.Schema](),
 		gvkToDefinitionName: cmap.New[string](),
 		kindToAPIVersions:   cmap.New[apiVersions](),
+		mergeSchemas:        patch.NewSchemas(),
+		crdMergeSchemas:     patch.SchemaSet{},
 	}
 
 	apiResourceLists, preferredAPIResourcesLists, err := getAPIResourceLists()
@@ -299,16 +308,32 @@ func (o *manager) DeleteCRDFromPreviousSync() {
 		o.gvkToDefinitionName.Remove(crd)
 		o.definitions.Remove(crd)
 	}
+	// the merge schemas in use are kept until the new ones are published by UpdateMergeSchemas
+	o.crdMergeSchemas = patch.SchemaSet{}
 
 	o.crdList = make([]string, 0)
 }
 
+// UpdateMergeSchemas replaces the merge schemas in use with the ones of the CRDs parsed since the previous sync
+func (o *manager) UpdateMergeSchemas() {
+	schemas := make(patch.SchemaSet, len(o.crdMergeSchemas))
+	for key, schema := range o.crdMergeSchemas {
+		schemas[key] = schema
+	}
+	o.mergeSchemas.Replace(schemas)
+}
+
+func (o *manager) MergeSchemas() patch.SchemaRegistry {
+	return o.mergeSchemas
+}
+
 // ParseCRD loads CRD to the cache
 func (o *manager) ParseCRD(crd unstructured.Unstructured) {
 	var err error
 
 	crdRaw, _ := json.Marshal(crd.Object)
 	_ = json.Unmarshal(crdRaw, &crdDefinitionPrior)
+	registerMergeSchemas(o.crdMergeSchemas, crdRaw)
 
 	openV3schema := crdDefinitionPrior.Spec.Validation.OpenAPIV3Schema
 	crdName := crdDefinitionPrior.Spec.Names.Kind
@@ -357,3 +382,35 @@ func (o *manager) ParseCRD(crd unstructured.Unstructured) {
 	o.gvkToDefinitionName.Set(crdName, crdName)
 	o.definitions.Set(crdName, parsedSchema)
 }
+
+// registerMergeSchemas adds the schemas of the CRD versions used by strategic merge patches
+func registerMergeSchemas(schemas patch.SchemaSet, crdRaw []byte) {
+	var crd crdVersionsDefinition
+	if err := json.Unmarshal(crdRaw, &crd); err != nil {
+		return
+	}
+	apiVersion := func(version string) string {
+		if crd.Spec.Group == "" {
+			return version
+		}
+		return crd.Spec.Group + "/" + version
+	}
+	register := func(version string, schema interface{}) {
+		if version == "" || schema == nil {
+			return
+		}
+		if err := schemas.Add(apiVersion(version), crd.Spec.Names.Kind, schema); err != nil {
+			logging.Error(err, "failed to register crd merge schema", "name", crd.Spec.Names.Kind, "version", version)
+		}
+	}
+	// prior to 1.16, a single schema applies to all the versions
+	if schema := crd.Spec.Validation.OpenAPIV3Schema; schema != nil {
+		register(crd.Spec.Version, schema)
+		for _, version := range crd.Spec.Versions {
+			register(version.Name, schema)
+		}
+	}
+	for _, version := range crd.Spec.Versions {
+		register(version.Name, version.Schema.OpenAPIV3Schema)
+	}
+}
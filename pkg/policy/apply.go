@@ -14,6 +14,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
@@ -29,6 +30,7 @@ func applyPolicy(
 	client dclient.Interface,
 	rclient registryclient.Client,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	mergeSchemas patch.SchemaRegistry,
 	namespaceLabels map[string]string,
 ) (responses []*response.EngineResponse) {
 	startTime := time.Now()
@@ -65,7 +67,7 @@ func applyPolicy(
 		logger.Error(err, "unable to set operation in context")
 	}
 
-	engineResponseMutation, err = mutation(policy, resource, logger, ctx, rclient, informerCacheResolvers, namespaceLabels)
+	engineResponseMutation, err = mutation(policy, resource, logger, ctx, rclient, informerCacheResolvers, mergeSchemas, namespaceLabels)
 	if err != nil {
 		logger.Error(err, "failed to process mutation rule")
 	}
@@ -91,13 +93,15 @@ func mutation(
 	jsonContext enginecontext.Interface,
 	rclient registryclient.Client,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	mergeSchemas patch.SchemaRegistry,
 	namespaceLabels map[string]string,
 ) (*response.EngineResponse, error) {
 	policyContext := engine.NewPolicyContextWithJsonContext(jsonContext).
 		WithPolicy(policy).
 		WithNamespaceLabels(namespaceLabels).
 		WithNewResource(resource).
-		WithInformerCacheResolver(informerCacheResolvers)
+		WithInformerCacheResolver(informerCacheResolvers).
+		WithMergeSchemas(mergeSchemas)
 
 	engineResponse := engine.Mutate(context.TODO(), rclient, policyContext)
 	if !engineResponse.IsSuccessful() {
@@ -23,6 +23,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -80,6 +81,9 @@ type PolicyController struct {
 
 	informerCacheResolvers resolvers.ConfigmapResolver
 
+	// mergeSchemas provides the schemas used to merge lists of custom resources
+	mergeSchemas patch.SchemaRegistry
+
 	informersSynced []cache.InformerSynced
 
 	// Resource manager, manages the mapping for already processed resource
@@ -107,6 +111,7 @@ func NewPolicyController(
 	eventGen event.Interface,
 	namespaces corev1informers.NamespaceInformer,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	mergeSchemas patch.SchemaRegistry,
 	log logr.Logger,
 	reconcilePeriod time.Duration,
 	metricsConfig metrics.MetricsConfigManager,
@@ -128,6 +133,7 @@ func NewPolicyController(
 		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "policy"),
 		configHandler:          configHandler,
 		informerCacheResolvers: informerCacheResolvers,
+		mergeSchemas:           mergeSchemas,
 		reconcilePeriod:        reconcilePeriod,
 		metricsConfig:          metricsConfig,
 		log:                    log,
@@ -15,6 +15,10 @@ func (pc *PolicyController) report(engineResponses []*response.EngineResponse, l
 	eventInfos := generateFailEvents(logger, engineResponses)
 	pc.eventGen.Add(eventInfos...)
 
+	for _, er := range engineResponses {
+		pc.eventGen.Add(event.NewPolicyExceptionEvents(event.PolicyController, er)...)
+	}
+
 	if pc.configHandler.GetGenerateSuccessEvents() {
 		successEventInfos := generateSuccessEvents(logger, engineResponses)
 		pc.eventGen.Add(successEventInfos...)
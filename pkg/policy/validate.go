@@ -872,6 +872,12 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 			return fmt.Sprintf("validate.%s", path), err
 		}
 	}
+	// validating the selectors and preconditions of the mutate targets, if they exist
+	for i := range rule.Mutation.Targets {
+		if path, err := validateTarget(rule.Mutation.Targets[i]); err != nil {
+			return fmt.Sprintf("mutate.targets[%d].%s", i, path), err
+		}
+	}
 	// validating the values present under validate.conditions, if they exist
 	if rule.Validation.Deny != nil {
 		if target := rule.Validation.Deny.GetAnyAllConditions(); target != nil {
@@ -883,6 +889,26 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 	return "", nil
 }
 
+// validateTarget validates the label selectors and the preconditions of a mutate target
+func validateTarget(target kyvernov1.TargetResourceSpec) (string, error) {
+	if target.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(target.Selector); err != nil {
+			return "selector", err
+		}
+	}
+	if target.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(target.NamespaceSelector); err != nil {
+			return "namespaceSelector", err
+		}
+	}
+	if conditions := target.GetAnyAllConditions(); conditions != nil {
+		if path, err := validateConditions(conditions, "preconditions"); err != nil {
+			return path, err
+		}
+	}
+	return "", nil
+}
+
 // validateConditions validates all the 'conditions' or 'preconditions' of a rule depending on the corresponding 'condition.key'.
 // As of now, it is validating the 'value' field whether it contains the only allowed set of values or not when 'condition.key' is {{request.operation}}
 // this is backwards compatible i.e. conditions can be provided in the old manner as well i.e. without 'any' or 'all'
@@ -993,11 +1019,12 @@ func validateConditionValuesKeyRequestOperation(c kyvernov1.Condition) (string,
 }
 
 func validateRuleContext(rule kyvernov1.Rule) error {
-	if rule.Context == nil || len(rule.Context) == 0 {
-		return nil
-	}
+	return ValidateContext(rule.Context)
+}
 
-	for _, entry := range rule.Context {
+// ValidateContext checks the context entries declarations
+func ValidateContext(entries []kyvernov1.ContextEntry) error {
+	for _, entry := range entries {
 		if entry.Name == "" {
 			return fmt.Errorf("a name is required for context entries")
 		}
@@ -2279,3 +2279,52 @@ func Test_Any_wildcard_policy(t *testing.T) {
 	_, err = Validate(policy, nil, true, openApiManager)
 	assert.Assert(t, err != nil)
 }
+
+func Test_ValidateTarget(t *testing.T) {
+	testcases := []struct {
+		description string
+		target      kyverno.TargetResourceSpec
+		path        string
+		wantErr     bool
+	}{
+		{
+			description: "valid target",
+			target: kyverno.TargetResourceSpec{
+				Selector:            &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+				NamespaceSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				RawAnyAllConditions: &apiextv1.JSON{Raw: []byte(`{"all":[{"key":"{{ target.metadata.name }}","operator":"Equals","value":"foo"}]}`)},
+			},
+		},
+		{
+			description: "invalid selector",
+			target: kyverno.TargetResourceSpec{
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}}},
+			},
+			path:    "selector",
+			wantErr: true,
+		},
+		{
+			description: "invalid namespace selector",
+			target: kyverno.TargetResourceSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "not a valid value"}},
+			},
+			path:    "namespaceSelector",
+			wantErr: true,
+		},
+		{
+			description: "invalid preconditions",
+			target: kyverno.TargetResourceSpec{
+				RawAnyAllConditions: &apiextv1.JSON{Raw: []byte(`{"all":[{"key":"{{ target.metadata.name }}","value":"foo"}]}`)},
+			},
+			path:    "preconditions.all[0].",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.description, func(t *testing.T) {
+			path, err := validateTarget(tc.target)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, path, tc.path)
+		})
+	}
+}
@@ -79,8 +79,9 @@ func parseVersion(rule *kyvernov1.PodSecurity) (*api.LevelVersion, error) {
 	}, nil
 }
 
-// EvaluatePod applies PSS checks to the pod and exempts controls specified in the rule
-func EvaluatePod(rule *kyvernov1.PodSecurity, pod *corev1.Pod) (bool, []pssutils.PSSCheckResult, error) {
+// EvaluatePod applies PSS checks to the pod and exempts controls specified in the rule,
+// additional exclusions (coming from policy exceptions for example) are merged with the rule ones
+func EvaluatePod(rule *kyvernov1.PodSecurity, pod *corev1.Pod, exclusions ...kyvernov1.PodSecurityStandard) (bool, []pssutils.PSSCheckResult, error) {
 	level, err := parseVersion(rule)
 	if err != nil {
 		return false, nil, err
@@ -88,7 +89,10 @@ func EvaluatePod(rule *kyvernov1.PodSecurity, pod *corev1.Pod) (bool, []pssutils
 
 	defaultCheckResults := evaluatePSS(level, *pod)
 
-	for _, exclude := range rule.Exclude {
+	excludes := make([]kyvernov1.PodSecurityStandard, 0, len(rule.Exclude)+len(exclusions))
+	excludes = append(excludes, rule.Exclude...)
+	excludes = append(excludes, exclusions...)
+	for _, exclude := range excludes {
 		spec, matching := GetPodWithMatchingContainers(exclude, pod)
 
 		switch {
@@ -58,6 +58,66 @@ func Test_EvaluatePod(t *testing.T) {
 	}
 }
 
+func Test_EvaluatePod_Exclusions(t *testing.T) {
+	rawPod := []byte(`
+	{
+		"kind": "Pod",
+		"metadata": {
+			"name": "test"
+		},
+		"spec": {
+			"hostNetwork": true,
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "nginx",
+					"securityContext": {
+						"privileged": true
+					}
+				}
+			]
+		}
+	}`)
+	var pod corev1.Pod
+	assert.NilError(t, json.Unmarshal(rawPod, &pod))
+	rule := kyvernov1.PodSecurity{
+		Level:   "baseline",
+		Version: "v1.24",
+		Exclude: []kyvernov1.PodSecurityStandard{{
+			ControlName: "Host Namespaces",
+		}},
+	}
+	tests := []struct {
+		name       string
+		exclusions []kyvernov1.PodSecurityStandard
+		allowed    bool
+	}{{
+		name: "no exclusions",
+	}, {
+		name: "container level exclusion",
+		exclusions: []kyvernov1.PodSecurityStandard{{
+			ControlName: "Privileged Containers",
+			Images:      []string{"nginx"},
+		}},
+		allowed: true,
+	}, {
+		name: "container level exclusion for other images",
+		exclusions: []kyvernov1.PodSecurityStandard{{
+			ControlName: "Privileged Containers",
+			Images:      []string{"busybox"},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, _, err := EvaluatePod(&rule, &pod, tt.exclusions...)
+			assert.NilError(t, err)
+			assert.Equal(t, allowed, tt.allowed)
+			// the rule exclusions must not be modified
+			assert.Equal(t, len(rule.Exclude), 1)
+		})
+	}
+}
+
 var baseline_hostProcess = []testCase{
 	{
 		name: "baseline_hostProcess_defines_all_violate_true",
@@ -5,17 +5,25 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	yamlv2 "gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
 	PolicyAnnotation = "policies.kyverno.io/last-applied-patches"
 	policyAnnotation = "policies.kyverno.io~1last-applied-patches"
 	oldAnnotation    = "policies.kyverno.io~1patches"
+	// AppliedMutationsAnnotation lists the policy rules that mutated a resource and the top-level paths they changed
+	AppliedMutationsAnnotation = "policies.kyverno.io/applied-mutations"
+	appliedMutationsAnnotation = "policies.kyverno.io~1applied-mutations"
 )
 
+// AppliedMutations maps a policy rule (`[namespace/]policy/rule`) to the top-level paths it changed
+type AppliedMutations map[string][]string
+
 type RulePatch struct {
 	RuleName string `json:"rulename"`
 	Op       string `json:"op"`
@@ -140,3 +148,95 @@ func annotationFromPolicyResponse(policyResponse response.PolicyResponse, log lo
 	}
 	return RulePatches
 }
+
+// AppliedMutationsFromRules returns the top-level paths changed by the rules of a policy
+func AppliedMutationsFromRules(policy kyvernov1.PolicyInterface, rules []response.RuleResponse) (AppliedMutations, error) {
+	mutations := AppliedMutations{}
+	policyName := policy.GetName()
+	if policy.GetNamespace() != "" {
+		policyName = policy.GetNamespace() + "/" + policy.GetName()
+	}
+	for _, rule := range rules {
+		if rule.Status != response.RuleStatusPass {
+			continue
+		}
+		for _, patch := range rule.Patches {
+			operation, err := jsonutils.UnmarshalPatchOperation(patch)
+			if err != nil {
+				return nil, err
+			}
+			mutations.add(policyName+"/"+rule.Name, topLevelPath(operation.Path))
+		}
+	}
+	return mutations, nil
+}
+
+func (m AppliedMutations) add(key string, paths ...string) {
+	m[key] = sets.NewString(m[key]...).Insert(paths...).List()
+}
+
+// Merge adds the entries of other to the applied mutations
+func (m AppliedMutations) Merge(other AppliedMutations) {
+	for key, paths := range other {
+		m.add(key, paths...)
+	}
+}
+
+// MergeAppliedMutationsAnnotation merges the applied mutations with the ones recorded in the annotations
+// and returns the new annotation value, invalid recorded values are discarded
+func MergeAppliedMutationsAnnotation(annotations map[string]string, mutations AppliedMutations) string {
+	merged := AppliedMutations{}
+	if value, ok := annotations[AppliedMutationsAnnotation]; ok {
+		var existing AppliedMutations
+		if err := json.Unmarshal([]byte(value), &existing); err == nil {
+			merged.Merge(existing)
+		}
+	}
+	merged.Merge(mutations)
+	result, _ := json.Marshal(merged)
+	return string(result)
+}
+
+// GenerateAppliedMutationsPatches returns the patches recording the mutations of the policies that opted in with annotateMutations,
+// annotationsExist tells if the annotations map is present in the resource once previous patches are applied
+func GenerateAppliedMutationsPatches(engineResponses []*response.EngineResponse, annotationsExist bool, log logr.Logger) [][]byte {
+	mutations := AppliedMutations{}
+	var annotations map[string]string
+	for _, er := range engineResponses {
+		annotations = er.PatchedResource.GetAnnotations()
+		if er.Policy == nil || !er.Policy.GetSpec().IsAnnotateMutations() || !er.IsSuccessful() {
+			continue
+		}
+		policyMutations, err := AppliedMutationsFromRules(er.Policy, er.PolicyResponse.Rules)
+		if err != nil {
+			log.Error(err, "failed to parse JSON patch bytes", "policy", er.Policy.GetName())
+			continue
+		}
+		mutations.Merge(policyMutations)
+	}
+	if len(mutations) == 0 {
+		return nil
+	}
+	value := MergeAppliedMutationsAnnotation(annotations, mutations)
+	var patchResponse jsonutils.PatchOperation
+	if annotationsExist || len(annotations) > 0 {
+		patchResponse = jsonutils.NewPatchOperation("/metadata/annotations/"+appliedMutationsAnnotation, "add", value)
+	} else {
+		patchResponse = jsonutils.NewPatchOperation("/metadata/annotations", "add", map[string]string{AppliedMutationsAnnotation: value})
+	}
+	patchByte, err := json.Marshal(patchResponse)
+	if err != nil {
+		log.Error(err, "failed to build JSON patch for annotation")
+		return nil
+	}
+	return [][]byte{patchByte}
+}
+
+// topLevelPath returns the first two segments of a JSON pointer, e.g. /spec/containers
+func topLevelPath(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -3,9 +3,11 @@ package utils
 import (
 	"testing"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -103,3 +105,31 @@ func Test_exist_patches(t *testing.T) {
 	assert.Equal(t, string(annPatches[0]), expectedPatches1)
 	assert.Equal(t, string(annPatches[1]), expectedPatches2)
 }
+
+func Test_applied_mutations_opt_out(t *testing.T) {
+	patchStr := `{ "op": "replace", "path": "/spec/containers/0/imagePullPolicy", "value": "IfNotPresent" }`
+	engineResponse := newEngineResponse("mutate-container", "default-imagepullpolicy", []string{patchStr}, response.RuleStatusPass, nil)
+	engineResponse.Policy = &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "mutate-container"}}
+	annPatches := GenerateAppliedMutationsPatches([]*response.EngineResponse{engineResponse}, false, logging.GlobalLogger())
+	assert.Assert(t, annPatches == nil)
+}
+
+func Test_applied_mutations(t *testing.T) {
+	annotation := map[string]interface{}{
+		"policies.kyverno.io/applied-mutations": `{"default/other/rule":["/metadata/labels"]}`,
+	}
+	patches := []string{
+		`{ "op": "replace", "path": "/spec/containers/0/imagePullPolicy", "value": "IfNotPresent" }`,
+		`{ "op": "add", "path": "/spec/containers/1", "value": {"name": "sidecar"} }`,
+		`{ "op": "add", "path": "/metadata/labels/foo", "value": "bar" }`,
+	}
+	engineResponse := newEngineResponse("mutate-container", "default-imagepullpolicy", patches, response.RuleStatusPass, annotation)
+	engineResponse.Policy = &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mutate-container"},
+		Spec:       kyvernov1.Spec{AnnotateMutations: true},
+	}
+	annPatches := GenerateAppliedMutationsPatches([]*response.EngineResponse{engineResponse}, true, logging.GlobalLogger())
+	expectedPatches := `{"path":"/metadata/annotations/policies.kyverno.io~1applied-mutations","op":"add","value":"{\"default/mutate-container/default-imagepullpolicy\":[\"/metadata/labels\",\"/spec/containers\"],\"default/other/rule\":[\"/metadata/labels\"]}"}`
+	assert.Equal(t, len(annPatches), 1)
+	assert.Equal(t, string(annPatches[0]), expectedPatches)
+}
@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"sort"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// SortByOrder returns a copy of the policies sorted by order,
+// policies with the same order are sorted by name then namespace
+func SortByOrder(policies []kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	sorted := make([]kyvernov1.PolicyInterface, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		left, right := sorted[i], sorted[j]
+		if left.GetSpec().GetOrder() != right.GetSpec().GetOrder() {
+			return left.GetSpec().GetOrder() < right.GetSpec().GetOrder()
+		}
+		if left.GetName() != right.GetName() {
+			return left.GetName() < right.GetName()
+		}
+		return left.GetNamespace() < right.GetNamespace()
+	})
+	return sorted
+}
@@ -0,0 +1,34 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortByOrder(t *testing.T) {
+	newPolicy := func(namespace, name string, order int32) kyvernov1.PolicyInterface {
+		meta := metav1.ObjectMeta{Namespace: namespace, Name: name}
+		spec := kyvernov1.Spec{Order: order}
+		if namespace == "" {
+			return &kyvernov1.ClusterPolicy{ObjectMeta: meta, Spec: spec}
+		}
+		return &kyvernov1.Policy{ObjectMeta: meta, Spec: spec}
+	}
+	policies := []kyvernov1.PolicyInterface{
+		newPolicy("", "c", 0),
+		newPolicy("", "b", 10),
+		newPolicy("test", "a", 0),
+		newPolicy("", "a", 0),
+		newPolicy("", "z", -5),
+	}
+	var names []string
+	for _, policy := range SortByOrder(policies) {
+		names = append(names, policy.GetNamespace()+"/"+policy.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"/z", "/a", "test/a", "/c", "/b"})
+	// input slice must be left untouched
+	assert.Equal(t, policies[0].GetName(), "c")
+}
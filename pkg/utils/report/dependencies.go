@@ -0,0 +1,55 @@
+package report
+
+import (
+	"encoding/json"
+
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// AnnotationConfigMaps contains the config maps read by policies when scanning the resource of a report,
+// it is a json object mapping policy labels to config map namespace/name keys
+const AnnotationConfigMaps = "audit.kyverno.io/configmaps"
+
+// GetConfigMapDependencies returns the config maps read by policies, indexed by policy label
+func GetConfigMapDependencies(report metav1.Object) map[string][]string {
+	dependencies := map[string][]string{}
+	if data, ok := report.GetAnnotations()[AnnotationConfigMaps]; ok {
+		// an invalid annotation is dropped on the next scan
+		_ = json.Unmarshal([]byte(data), &dependencies)
+	}
+	return dependencies
+}
+
+// SetConfigMapDependencies sets the config maps read by policies, the annotation is removed if there's none
+func SetConfigMapDependencies(report metav1.Object, dependencies map[string][]string) {
+	for label, configMaps := range dependencies {
+		if len(configMaps) == 0 {
+			delete(dependencies, label)
+		}
+	}
+	if len(dependencies) == 0 {
+		annotations := report.GetAnnotations()
+		delete(annotations, AnnotationConfigMaps)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		report.SetAnnotations(annotations)
+		return
+	}
+	data, err := json.Marshal(dependencies)
+	if err != nil {
+		return
+	}
+	controllerutils.SetAnnotation(report, AnnotationConfigMaps, string(data))
+}
+
+// ConfigMapDependencies returns all the config maps read by policies
+func ConfigMapDependencies(report metav1.Object) []string {
+	configMaps := sets.NewString()
+	for _, keys := range GetConfigMapDependencies(report) {
+		configMaps.Insert(keys...)
+	}
+	return configMaps.List()
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -26,6 +28,10 @@ const (
 	LabelPrefixPolicy        = LabelDomainPolicy + "/"
 	//	aggregated admission report label
 	LabelAggregatedReport = "audit.kyverno.io/report.aggregate"
+	//	background scan report label, set when a rescan was requested and removed once done
+	LabelScanRequested = "audit.kyverno.io/scan.requested"
+	//	result labels, set on per resource policy reports
+	LabelPrefixResult = "audit.kyverno.io/result."
 )
 
 func IsPolicyLabel(label string) bool {
@@ -101,6 +107,21 @@ func SetPolicyLabel(report kyvernov1alpha2.ReportInterface, policy kyvernov1.Pol
 	controllerutils.SetLabel(report, PolicyLabel(policy), policy.GetResourceVersion())
 }
 
+// SetResultLabels sets a label per result status with the number of results having this status
+func SetResultLabels(report kyvernov1alpha2.ReportInterface, results ...policyreportv1alpha2.PolicyReportResult) {
+	counts := map[policyreportv1alpha2.PolicyResult]int{}
+	for _, result := range results {
+		counts[result.Result]++
+	}
+	for status, count := range counts {
+		controllerutils.SetLabel(report, ResultLabel(status), strconv.Itoa(count))
+	}
+}
+
+func ResultLabel(status policyreportv1alpha2.PolicyResult) string {
+	return LabelPrefixResult + string(status)
+}
+
 func GetResourceUid(report metav1.Object) types.UID {
 	return types.UID(report.GetLabels()[LabelResourceUid])
 }
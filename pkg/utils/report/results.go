@@ -9,10 +9,62 @@ import (
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 )
 
+// ExceptionProperty is the result property holding the namespace/name of the policy exception that caused a rule to be skipped
+const ExceptionProperty = "exception"
+
+// FirstSeenProperty is the result property holding the time (RFC3339) a result was first reported,
+// the result timestamp holds the time of its last transition
+const FirstSeenProperty = "firstSeen"
+
+// owner properties hold the top level owner of the resource of a result, resolved through owner references
+const (
+	OwnerAPIVersionProperty = "ownerApiVersion"
+	OwnerKindProperty       = "ownerKind"
+	OwnerNameProperty       = "ownerName"
+	OwnerUIDProperty        = "ownerUid"
+)
+
+// InstancesProperty is the result property holding the number of resources aggregated into a result by owner
+const InstancesProperty = "instances"
+
+// SetOwnerProperties sets the top level owner properties of a result
+func SetOwnerProperties(result *policyreportv1alpha2.PolicyReportResult, owner metav1.OwnerReference) {
+	properties := map[string]string{}
+	for k, v := range result.Properties {
+		properties[k] = v
+	}
+	properties[OwnerAPIVersionProperty] = owner.APIVersion
+	properties[OwnerKindProperty] = owner.Kind
+	properties[OwnerNameProperty] = owner.Name
+	properties[OwnerUIDProperty] = string(owner.UID)
+	result.Properties = properties
+}
+
+// GetOwnerReference returns the top level owner of the resource of a result, if any
+func GetOwnerReference(result policyreportv1alpha2.PolicyReportResult) (corev1.ObjectReference, bool) {
+	uid, ok := result.Properties[OwnerUIDProperty]
+	if !ok {
+		return corev1.ObjectReference{}, false
+	}
+	var namespace string
+	if len(result.Resources) != 0 {
+		namespace = result.Resources[0].Namespace
+	}
+	return corev1.ObjectReference{
+		APIVersion: result.Properties[OwnerAPIVersionProperty],
+		Kind:       result.Properties[OwnerKindProperty],
+		Namespace:  namespace,
+		Name:       result.Properties[OwnerNameProperty],
+		UID:        types.UID(uid),
+	}, true
+}
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) bool {
 		if a.Policy != b.Policy {
@@ -97,6 +149,11 @@ func EngineResponseToReportResults(response *response.EngineResponse) []policyre
 			Category: annotations[kyvernov1.AnnotationPolicyCategory],
 			Severity: severityFromString(annotations[kyvernov1.AnnotationPolicySeverity]),
 		}
+		if ruleResult.Exception != "" {
+			result.Properties = map[string]string{
+				ExceptionProperty: ruleResult.Exception,
+			}
+		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
@@ -0,0 +1,73 @@
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	log "github.com/kyverno/kyverno/pkg/logging"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// GetCleanupPolicy extracts cleanup policies from YAML bytes
+func GetCleanupPolicy(bytes []byte) (policies []kyvernov2alpha1.CleanupPolicyInterface, err error) {
+	documents, err := SplitDocuments(bytes)
+	if err != nil {
+		return nil, err
+	}
+	for _, thisPolicyBytes := range documents {
+		policyBytes, err := yaml.ToJSON(thisPolicyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to JSON: %v", err)
+		}
+		us := &unstructured.Unstructured{}
+		if err := json.Unmarshal(policyBytes, us); err != nil {
+			return nil, fmt.Errorf("failed to decode policy: %v", err)
+		}
+		if us.IsList() {
+			list, err := us.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode policy list: %v", err)
+			}
+			for i := range list.Items {
+				item := list.Items[i]
+				if policies, err = addCleanupPolicy(policies, &item); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			if policies, err = addCleanupPolicy(policies, us); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return policies, nil
+}
+
+func addCleanupPolicy(policies []kyvernov2alpha1.CleanupPolicyInterface, us *unstructured.Unstructured) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	switch us.GetKind() {
+	case "":
+		log.V(3).Info("skipping file as policy.TypeMeta.Kind not found")
+		return policies, nil
+	case "ClusterCleanupPolicy":
+		policy := &kyvernov2alpha1.ClusterCleanupPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(us.Object, policy); err != nil {
+			return nil, fmt.Errorf("failed to decode policy: %v", err)
+		}
+		policy.Namespace = ""
+		return append(policies, policy), nil
+	case "CleanupPolicy":
+		policy := &kyvernov2alpha1.CleanupPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(us.Object, policy); err != nil {
+			return nil, fmt.Errorf("failed to decode policy: %v", err)
+		}
+		if policy.Namespace == "" {
+			policy.Namespace = "default"
+		}
+		return append(policies, policy), nil
+	default:
+		return nil, fmt.Errorf("resource %s/%s is not a CleanupPolicy or a ClusterCleanupPolicy", us.GetKind(), us.GetName())
+	}
+}
@@ -0,0 +1,49 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCleanupPolicy(t *testing.T) {
+	policies, err := GetCleanupPolicy([]byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: ClusterCleanupPolicy
+metadata:
+  name: cleanup-cluster
+spec:
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+  schedule: "* * * * *"
+---
+apiVersion: kyverno.io/v2alpha1
+kind: CleanupPolicy
+metadata:
+  name: cleanup
+spec:
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+  schedule: "* * * * *"
+`))
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, "ClusterCleanupPolicy", policies[0].GetKind())
+	assert.Equal(t, "", policies[0].GetNamespace())
+	assert.Equal(t, "CleanupPolicy", policies[1].GetKind())
+	assert.Equal(t, "default", policies[1].GetNamespace())
+
+	_, err = GetCleanupPolicy([]byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: policy
+`))
+	assert.Error(t, err)
+}
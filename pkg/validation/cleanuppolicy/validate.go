@@ -8,6 +8,7 @@ import (
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/auth"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	policyvalidation "github.com/kyverno/kyverno/pkg/policy"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 )
@@ -45,6 +46,9 @@ func Validate(ctx context.Context, logger logr.Logger, client dclient.Interface,
 	if err := validatePolicy(clusteredResources, policy); err != nil {
 		return err
 	}
+	if err := validateContext(policy); err != nil {
+		return err
+	}
 	if err := validateAuth(ctx, client, policy); err != nil {
 		return err
 	}
@@ -57,6 +61,19 @@ func validatePolicy(clusterResources sets.String, policy kyvernov2alpha1.Cleanup
 	return errs.ToAggregate()
 }
 
+// validateContext checks the context entries declarations
+func validateContext(policy kyvernov2alpha1.CleanupPolicyInterface) error {
+	for _, entry := range policy.GetSpec().Context {
+		if entry.ImageRegistry != nil {
+			return fmt.Errorf("invalid context: imageRegistry entry %s is not supported in cleanup policies", entry.Name)
+		}
+	}
+	if err := policyvalidation.ValidateContext(policy.GetSpec().Context); err != nil {
+		return fmt.Errorf("invalid context: %w", err)
+	}
+	return nil
+}
+
 // validateAuth checks the the delete action is allowed
 func validateAuth(ctx context.Context, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface) error {
 	namespace := policy.GetNamespace()
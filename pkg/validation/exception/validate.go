@@ -7,8 +7,14 @@ import (
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 )
 
+const noExpiryWarning = "the policy exception has no expiry, consider setting spec.expiresAt"
+
 // Validate checks policy exception is valid
-func Validate(ctx context.Context, logger logr.Logger, polex *kyvernov2alpha1.PolicyException) error {
+func Validate(ctx context.Context, logger logr.Logger, polex *kyvernov2alpha1.PolicyException) ([]string, error) {
+	var warnings []string
+	if polex.Spec.ExpiresAt == nil {
+		warnings = append(warnings, noExpiryWarning)
+	}
 	errs := polex.Validate()
-	return errs.ToAggregate()
+	return warnings, errs.ToAggregate()
 }
@@ -24,9 +24,9 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		logger.Error(err, "failed to unmarshal policy exceptions from admission request")
 		return admissionutils.Response(request.UID, err)
 	}
-	if err := validation.Validate(ctx, logger, polex); err != nil {
+	warnings, err := validation.Validate(ctx, logger, polex)
+	if err != nil {
 		logger.Error(err, "policy exception validation errors")
-		return admissionutils.Response(request.UID, err)
 	}
-	return nil
+	return admissionutils.Response(request.UID, err, warnings...)
 }
@@ -14,8 +14,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/config"
+	blockedreports "github.com/kyverno/kyverno/pkg/controllers/report/blocked"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	engineutils2 "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -56,11 +58,12 @@ type handlers struct {
 	urLister  kyvernov1beta1listers.UpdateRequestNamespaceLister
 	peLister  kyvernov2alpha1listers.PolicyExceptionLister
 
-	urGenerator    webhookgenerate.Generator
-	eventGen       event.Interface
-	openApiManager openapi.ValidateInterface
-	pcBuilder      webhookutils.PolicyContextBuilder
-	urUpdater      webhookutils.UpdateRequestUpdater
+	urGenerator     webhookgenerate.Generator
+	eventGen        event.Interface
+	openApiManager  openapi.ValidateInterface
+	pcBuilder       webhookutils.PolicyContextBuilder
+	urUpdater       webhookutils.UpdateRequestUpdater
+	blockedRequests blockedreports.Recorder
 
 	admissionReports bool
 }
@@ -81,6 +84,8 @@ func NewHandlers(
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
+	mergeSchemas patch.SchemaRegistry,
+	blockedRequests blockedreports.Recorder,
 	admissionReports bool,
 ) webhooks.ResourceHandlers {
 	return &handlers{
@@ -98,8 +103,9 @@ func NewHandlers(
 		urGenerator:      urGenerator,
 		eventGen:         eventGen,
 		openApiManager:   openApiManager,
-		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister, informerCacheResolvers, peLister),
+		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister, informerCacheResolvers, peLister, mergeSchemas),
 		urUpdater:        webhookutils.NewUpdateRequestUpdater(kyvernoClient, urLister),
+		blockedRequests:  blockedRequests,
 		admissionReports: admissionReports,
 	}
 }
@@ -137,7 +143,7 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		namespaceLabels = common.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
 	}
 
-	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.rclient, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig)
+	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.rclient, h.pCache, h.pcBuilder, h.eventGen, h.blockedRequests, h.admissionReports, h.metricsConfig)
 
 	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, namespaceLabels, startTime)
 	if !ok {
@@ -184,7 +190,7 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
-	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.rclient, h.eventGen, h.admissionReports)
+	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.rclient, h.eventGen, h.blockedRequests, h.admissionReports)
 	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
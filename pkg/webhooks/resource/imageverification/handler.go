@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	blockedreports "github.com/kyverno/kyverno/pkg/controllers/report/blocked"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -34,6 +35,7 @@ type imageVerificationHandler struct {
 	rclient          registryclient.Client
 	log              logr.Logger
 	eventGen         event.Interface
+	blockedRequests  blockedreports.Recorder
 	admissionReports bool
 }
 
@@ -42,6 +44,7 @@ func NewImageVerificationHandler(
 	kyvernoClient versioned.Interface,
 	rclient registryclient.Client,
 	eventGen event.Interface,
+	blockedRequests blockedreports.Recorder,
 	admissionReports bool,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
@@ -49,6 +52,7 @@ func NewImageVerificationHandler(
 		rclient:          rclient,
 		log:              log,
 		eventGen:         eventGen,
+		blockedRequests:  blockedRequests,
 		admissionReports: admissionReports,
 	}
 }
@@ -105,6 +109,9 @@ func (h *imageVerificationHandler) handleVerifyImages(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
+		if h.blockedRequests != nil {
+			h.blockedRequests.Record(request, failurePolicy, engineResponses...)
+		}
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
 	}
 
@@ -10,15 +10,18 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/common"
 	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/metrics/mutationconflicts"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
@@ -69,34 +72,40 @@ func (h *mutationHandler) HandleMutation(
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
 ) ([]byte, []string, error) {
-	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext)
+	mutatePatches, mutateEngineResponses, conflicts, err := h.applyMutations(ctx, request, policies, policyContext)
 	if err != nil {
 		return nil, nil, err
 	}
 	h.log.V(6).Info("", "generated patches", string(mutatePatches))
-	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
+	warnings := webhookutils.GetWarningMessages(mutateEngineResponses)
+	for _, conflict := range conflicts {
+		warnings = append(warnings, conflict.String())
+	}
+	return mutatePatches, warnings, nil
 }
 
 // applyMutations handles mutating webhook admission request
-// return value: generated patches, triggered policies, engine responses correspdonding to the triggered policies
+// return value: generated patches, engine responses correspdonding to the triggered policies, conflicting mutations
 func (v *mutationHandler) applyMutations(
 	ctx context.Context,
 	request *admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
-) ([]byte, []*response.EngineResponse, error) {
+) ([]byte, []*response.EngineResponse, []patch.Conflict, error) {
 	if len(policies) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	if isResourceDeleted(policyContext) && request.Operation == admissionv1.Update {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 
 	var patches [][]byte
 	var engineResponses []*response.EngineResponse
+	var conflicts []patch.Conflict
+	tracker := patch.NewConflictTracker()
 
-	for _, policy := range policies {
+	for _, policy := range policyutils.SortByOrder(policies) {
 		spec := policy.GetSpec()
 		if !spec.HasMutate() {
 			continue
@@ -122,6 +131,15 @@ func (v *mutationHandler) applyMutations(
 					}
 				}
 
+				for _, rule := range engineResponse.PolicyResponse.Rules {
+					writer := patch.PatchWriter{PolicyNamespace: policy.GetNamespace(), PolicyName: policy.GetName(), RuleName: rule.Name}
+					ruleConflicts, err := tracker.Track(writer, rule.Patches)
+					if err != nil {
+						v.log.Error(err, "failed to track mutation conflicts", "policy", policy.GetName(), "rule", rule.Name)
+					}
+					conflicts = append(conflicts, ruleConflicts...)
+				}
+
 				policyContext = currentContext.WithNewResource(engineResponse.PatchedResource)
 				engineResponses = append(engineResponses, engineResponse)
 
@@ -134,24 +152,38 @@ func (v *mutationHandler) applyMutations(
 			},
 		)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	// generate annotations
-	if annPatches := utils.GenerateAnnotationPatches(engineResponses, v.log); annPatches != nil {
+	annPatches := utils.GenerateAnnotationPatches(engineResponses, v.log)
+	if annPatches != nil {
 		patches = append(patches, annPatches...)
 	}
+	if mutationPatches := utils.GenerateAppliedMutationsPatches(engineResponses, annPatches != nil, v.log); mutationPatches != nil {
+		patches = append(patches, mutationPatches...)
+	}
 
 	if !isResourceDeleted(policyContext) {
 		events := webhookutils.GenerateEvents(engineResponses, false)
 		v.eventGen.Add(events...)
 	}
 
+	if len(conflicts) != 0 {
+		resource := engineResponses[len(engineResponses)-1].GetResourceSpec()
+		for _, conflict := range conflicts {
+			v.log.Info("mutation conflict detected", "path", conflict.Path, "policy", conflict.Writer, "overwritten", conflict.Overwritten)
+			v.eventGen.Add(event.NewMutationConflictEvent(event.AdmissionController, conflict.Writer.PolicyNamespace, conflict.Writer.PolicyName, resource, conflict.String()))
+		}
+		// registering the kyverno_mutation_conflicts_total metric concurrently
+		go mutationconflicts.ProcessConflicts(context.TODO(), v.metrics, request.Kind.Kind, request.Namespace, conflicts)
+	}
+
 	logMutationResponse(patches, engineResponses, v.log)
 
 	// patches holds all the successful patches, if no patch is created, it returns nil
-	return jsonutils.JoinPatches(patches...), engineResponses, nil
+	return jsonutils.JoinPatches(patches...), engineResponses, conflicts, nil
 }
 
 func (h *mutationHandler) applyMutation(ctx context.Context, request *admissionv1.AdmissionRequest, policyContext *engine.PolicyContext) (*response.EngineResponse, [][]byte, error) {
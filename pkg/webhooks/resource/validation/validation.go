@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	blockedreports "github.com/kyverno/kyverno/pkg/controllers/report/blocked"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -41,6 +42,7 @@ func NewValidationHandler(
 	pCache policycache.Cache,
 	pcBuilder webhookutils.PolicyContextBuilder,
 	eventGen event.Interface,
+	blockedRequests blockedreports.Recorder,
 	admissionReports bool,
 	metrics metrics.MetricsConfigManager,
 ) ValidationHandler {
@@ -51,6 +53,7 @@ func NewValidationHandler(
 		pCache:           pCache,
 		pcBuilder:        pcBuilder,
 		eventGen:         eventGen,
+		blockedRequests:  blockedRequests,
 		admissionReports: admissionReports,
 		metrics:          metrics,
 	}
@@ -63,6 +66,7 @@ type validationHandler struct {
 	pCache           policycache.Cache
 	pcBuilder        webhookutils.PolicyContextBuilder
 	eventGen         event.Interface
+	blockedRequests  blockedreports.Recorder
 	admissionReports bool
 	metrics          metrics.MetricsConfigManager
 }
@@ -141,6 +145,9 @@ func (v *validationHandler) HandleValidation(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
+		if v.blockedRequests != nil {
+			v.blockedRequests.Record(request, failurePolicy, engineResponses...)
+		}
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil
 	}
 
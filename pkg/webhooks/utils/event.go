@@ -16,12 +16,16 @@ func GenerateEvents(engineResponses []*response.EngineResponse, blocked bool) []
 	//     - report success event on resource
 	//   - Some/All policies skipped
 	//     - report skipped event on resource
+	//   - Some/All rules skipped because of policy exceptions
+	//     - report exception event on resource
 
 	for _, er := range engineResponses {
 		if er.IsEmpty() {
 			continue
 		}
 
+		events = append(events, event.NewPolicyExceptionEvents(event.AdmissionController, er)...)
+
 		if !er.IsSuccessful() {
 			for i, ruleResp := range er.PolicyResponse.Rules {
 				if ruleResp.Status == response.RuleStatusFail || ruleResp.Status == response.RuleStatusError {
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGenerateEvents_PolicyException(t *testing.T) {
+	engineResponses := []*response.EngineResponse{{
+		Policy: &v1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+		},
+		PatchedResource: unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      "pod",
+				"namespace": "default",
+			},
+		}},
+		PolicyResponse: response.PolicyResponse{
+			Rules: []response.RuleResponse{{
+				Name:      "rule",
+				Status:    response.RuleStatusSkip,
+				Exception: "default/exception",
+			}, {
+				Name:   "other",
+				Status: response.RuleStatusSkip,
+			}},
+		},
+	}}
+	events := GenerateEvents(engineResponses, false)
+	assert.Len(t, events, 1)
+	assert.Equal(t, event.PolicyException.String(), events[0].Reason)
+	assert.Equal(t, "Pod", events[0].Kind)
+	assert.Equal(t, "default", events[0].Namespace)
+	assert.Equal(t, "pod", events[0].Name)
+	assert.Equal(t, "policy test/rule skipped due to policy exception default/exception", events[0].Message)
+}
@@ -14,6 +14,10 @@ func ExcludeKyvernoResources(kind string) bool {
 		return true
 	case "ClusterBackgroundScanReport":
 		return true
+	case "BlockedRequestReport":
+		return true
+	case "ClusterBlockedRequestReport":
+		return true
 	case "UpdateRequest":
 		return true
 	case "GenerateRequest":
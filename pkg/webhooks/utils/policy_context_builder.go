@@ -7,6 +7,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/userinfo"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -24,6 +25,7 @@ type policyContextBuilder struct {
 	crbLister              rbacv1listers.ClusterRoleBindingLister
 	informerCacheResolvers resolvers.ConfigmapResolver
 	peLister               kyvernov2alpha1listers.PolicyExceptionLister
+	mergeSchemas           patch.SchemaRegistry
 }
 
 func NewPolicyContextBuilder(
@@ -33,6 +35,7 @@ func NewPolicyContextBuilder(
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	informerCacheResolvers resolvers.ConfigmapResolver,
 	peLister kyvernov2alpha1listers.PolicyExceptionLister,
+	mergeSchemas patch.SchemaRegistry,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
 		configuration:          configuration,
@@ -41,6 +44,7 @@ func NewPolicyContextBuilder(
 		crbLister:              crbLister,
 		informerCacheResolvers: informerCacheResolvers,
 		peLister:               peLister,
+		mergeSchemas:           mergeSchemas,
 	}
 }
 
@@ -54,5 +58,9 @@ func (b *policyContextBuilder) Build(request *admissionv1.AdmissionRequest) (*en
 		userRequestInfo.Roles = roles
 		userRequestInfo.ClusterRoles = clusterRoles
 	}
-	return engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers, b.peLister)
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers, b.peLister)
+	if err != nil {
+		return nil, err
+	}
+	return policyContext.WithMergeSchemas(b.mergeSchemas), nil
 }